@@ -41,6 +41,8 @@ type Client interface {
 	Screenview(name string, params analytics.Params) error
 	Delete(path string) (bool, error)
 	Signal(path string, signal string) error
+	Forward(path string, ports []string) (<-chan apitypes.ForwardPacket, io.Closer, error)
+	Create(path string, body apitypes.CreateBody) (apitypes.Entry, error)
 }
 
 // A domainSocketClient is a wash API client.
@@ -316,3 +318,48 @@ func (c *domainSocketClient) Signal(path string, signal string) error {
 	_, err = c.doRequest(http.MethodPost, "/fs/signal", url.Values{"path": []string{path}}, bytes.NewReader(jsonBody))
 	return err
 }
+
+// Forward forwards the given port mappings to the entry at "path". The returned
+// channel receives the forward's status messages and is closed when forwarding stops.
+// If it stopped because of an error, the last packet's Err is set. Forwarding stops
+// when the returned closer is closed.
+func (c *domainSocketClient) Forward(path string, ports []string) (<-chan apitypes.ForwardPacket, io.Closer, error) {
+	payload := apitypes.ForwardBody{Ports: ports}
+	jsonBody, err := json.Marshal(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respBody, err := c.doRequest(http.MethodPost, "/fs/forward", url.Values{"path": []string{path}}, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	packets := make(chan apitypes.ForwardPacket, 1)
+	go func() {
+		defer close(packets)
+		decoder := json.NewDecoder(respBody)
+		for {
+			var pkt apitypes.ForwardPacket
+			if err := decoder.Decode(&pkt); err == io.EOF {
+				return
+			} else if err != nil {
+				packets <- apitypes.ForwardPacket{Err: &apitypes.ErrorObj{Kind: apitypes.StreamingError, Msg: err.Error()}}
+				return
+			}
+			packets <- pkt
+		}
+	}()
+	return packets, respBody, nil
+}
+
+// Create creates the child described by body in the entry at "path", and returns the new child
+func (c *domainSocketClient) Create(path string, body apitypes.CreateBody) (apitypes.Entry, error) {
+	var entry apitypes.Entry
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return entry, err
+	}
+	err = c.doRequestAndParseJSONBody(http.MethodPost, "/fs/create", url.Values{"path": []string{path}}, bytes.NewReader(jsonBody), &entry)
+	return entry, err
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/puppetlabs/wash/activity"
+	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// swagger:route POST /fs/create create createEntry
+//
+// Creates a child of the entry at the specified path.
+//
+// The child's a file with the given content, or an empty directory if
+// dir is true. Returns an Entry object describing the new child.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http
+//
+//     Responses:
+//       200: Entry
+//       400: errorResp
+//       404: errorResp
+//       500: errorResp
+var createHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
+	ctx := r.Context()
+	entry, path, errResp := getEntryFromRequest(r)
+	if errResp != nil {
+		return errResp
+	}
+
+	if r.Body == nil {
+		return badActionRequestResponse(path, plugin.CreateAction(), "Please send a JSON request body")
+	}
+
+	var body apitypes.CreateBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return badActionRequestResponse(path, plugin.CreateAction(), err.Error())
+	}
+
+	action := plugin.CreateAction()
+	if body.Dir {
+		action = plugin.MkdirAction()
+	}
+	if !action.IsSupportedOn(entry) {
+		return unsupportedActionResponse(path, action)
+	}
+
+	var child plugin.Entry
+	var err error
+	if body.Dir {
+		var dir plugin.Parent
+		dir, err = plugin.CreateDirWithAnalytics(ctx, entry.(plugin.DirCreatable), body.Name)
+		child = dir
+	} else {
+		child, err = plugin.CreateWithAnalytics(ctx, entry.(plugin.Creatable), body.Name, body.Content)
+	}
+	if err != nil {
+		if plugin.IsInvalidInputErr(err) {
+			return badActionRequestResponse(path, action, err.Error())
+		}
+		return erroredActionResponse(path, action, err.Error())
+	}
+
+	apiEntry := apitypes.NewEntry(child)
+	apiEntry.Path = path + "/" + apiEntry.CName
+	activity.Record(ctx, "API: Created %v", apiEntry.Path)
+
+	if err := json.NewEncoder(w).Encode(&apiEntry); err != nil {
+		return unknownErrorResponse(fmt.Errorf("Could not marshal %v: %v", apiEntry.Path, err))
+	}
+	return nil
+}}
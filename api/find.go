@@ -63,6 +63,10 @@ var findHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *erro
 	if errResp != nil {
 		return errResp
 	}
+	follow, errResp := getBoolParam(r.URL, "follow")
+	if errResp != nil {
+		return errResp
+	}
 	var rawQuery interface{}
 	if err := json.NewDecoder(r.Body).Decode(&rawQuery); err != nil {
 		if err != io.EOF {
@@ -83,6 +87,9 @@ var findHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *erro
 	if hasMaxDepth {
 		opts.Maxdepth = maxDepth
 	}
+	if follow {
+		opts.FollowLinks(ctx.Value(pluginRegistryKey).(*plugin.Registry))
+	}
 
 	rqlEntries, err := rql.Find(ctx, entry, query, opts)
 	if err != nil {
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/puppetlabs/wash/activity"
+	apitypes "github.com/puppetlabs/wash/api/types"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// swagger:response
+//nolint:deadcode,unused
+type forwardResponse struct {
+	// in: body
+	Packets []apitypes.ForwardPacket
+}
+
+// swagger:route POST /fs/forward forward forwardPorts
+//
+// Forwards local ports to the entry at the specified path.
+//
+// Forwarding continues until the request is cancelled. Status messages
+// are streamed in the response body as ForwardPacket objects. If
+// forwarding stops because of an error, the last packet includes it.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http
+//
+//     Responses:
+//       200: forwardResponse
+//       400: errorResp
+//       404: errorResp
+//       500: errorResp
+var forwardHandler = handler{fn: func(w http.ResponseWriter, r *http.Request) *errorResponse {
+	ctx := r.Context()
+	entry, path, errResp := getEntryFromRequest(r)
+	if errResp != nil {
+		return errResp
+	}
+
+	if !plugin.ForwardAction().IsSupportedOn(entry) {
+		return unsupportedActionResponse(path, plugin.ForwardAction())
+	}
+
+	if r.Body == nil {
+		return badActionRequestResponse(path, plugin.ForwardAction(), "Please send a JSON request body")
+	}
+
+	var body apitypes.ForwardBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return badActionRequestResponse(path, plugin.ForwardAction(), err.Error())
+	}
+
+	f, ok := w.(flushableWriter)
+	if !ok {
+		return unknownErrorResponse(fmt.Errorf("Cannot forward to %v, response handler does not support flushing", path))
+	}
+
+	activity.Record(ctx, "API: Forwarding %v to %v", body.Ports, path)
+	out := &forwardWriter{enc: json.NewEncoder(&streamableResponseWriter{f})}
+	err := plugin.ForwardWithAnalytics(ctx, entry.(plugin.Forwardable), body.Ports, out)
+	if err == nil {
+		activity.Record(ctx, "API: Forward to %v complete", path)
+		return nil
+	}
+
+	if !out.hasWritten() {
+		// Nothing's been sent yet, so we can still respond with a proper error.
+		if plugin.IsInvalidInputErr(err) {
+			return badActionRequestResponse(path, plugin.ForwardAction(), err.Error())
+		}
+		return erroredActionResponse(path, plugin.ForwardAction(), err.Error())
+	}
+
+	// The response has started, so report the error in a final packet.
+	activity.Record(ctx, "API: Forward to %v errored: %v", path, err)
+	errObj := erroredActionResponse(path, plugin.ForwardAction(), err.Error()).body
+	if err := out.send(apitypes.ForwardPacket{Err: errObj}); err != nil {
+		activity.Record(ctx, "API: Failed to report forward error for %v: %v", path, err)
+	}
+	return nil
+}}
+
+// forwardWriter sends each status message written by the plugin as a ForwardPacket.
+// It records whether anything was sent so callers know whether the response status
+// has already been sent. Forwarding writes status messages from a goroutine per
+// connection, so sends are serialized.
+type forwardWriter struct {
+	enc   *json.Encoder
+	mux   sync.Mutex
+	wrote bool
+}
+
+func (w *forwardWriter) Write(b []byte) (int, error) {
+	if err := w.send(apitypes.ForwardPacket{Data: string(b)}); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (w *forwardWriter) send(p apitypes.ForwardPacket) error {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.wrote = true
+	p.Timestamp = time.Now()
+	return w.enc.Encode(p)
+}
+
+func (w *forwardWriter) hasWritten() bool {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	return w.wrote
+}
@@ -31,12 +31,14 @@ type Entry struct {
 	apitypes.Entry
 	Schema      *EntrySchema
 	pluginEntry plugin.Entry
+	parent      *Entry
 }
 
 func newEntry(parent *Entry, pluginEntry plugin.Entry) Entry {
 	e := Entry{
 		Entry:       apitypes.NewEntry(pluginEntry),
 		pluginEntry: pluginEntry,
+		parent:      parent,
 	}
 	if parent == nil {
 		// This is the root
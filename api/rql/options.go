@@ -1,5 +1,7 @@
 package rql
 
+import "github.com/puppetlabs/wash/plugin"
+
 // Options represent the RQL's options
 type Options struct {
 	// Mindepth is the minimum depth. Descendants at lesser depths are not included
@@ -21,6 +23,20 @@ type Options struct {
 	// where N is the number of visited entries. Using the partial metadata (unsetting Fullmeta)
 	// does not result in any extra request.
 	Fullmeta bool
+	// Follow is short for "follow links". If set, then links are walked as if they were the
+	// entries that they link to, except that they keep the link's path. Links that point
+	// back to one of their ancestors aren't followed, to avoid walking forever.
+	//
+	// Following links requires the plugin registry to find the linked entries. Use
+	// FollowLinks to set it.
+	Follow   bool
+	registry *plugin.Registry
+}
+
+// FollowLinks sets the Follow option. Linked entries are found from the registry.
+func (opts *Options) FollowLinks(registry *plugin.Registry) {
+	opts.Follow = true
+	opts.registry = registry
 }
 
 // DefaultMaxdepth is the default value of the maxdepth option.
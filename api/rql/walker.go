@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 
+	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/plugin"
 )
 
@@ -72,7 +74,23 @@ func (w *walkerImpl) walk(ctx context.Context, e *Entry, depth int) ([]Entry, er
 			return nil, fmt.Errorf("could not get children of %v: %w\n", e.Path, err)
 		} else {
 			children := []Entry{}
+			var followErr error
 			childrenMap.Range(func(cname string, childPluginEntry plugin.Entry) bool {
+				if l, ok := childPluginEntry.(plugin.Linkable); ok && w.opts.Follow {
+					var child *Entry
+					var followed bool
+					child, followed, followErr = w.follow(ctx, e, l)
+					if followErr != nil {
+						return false
+					}
+					if followed {
+						if child != nil {
+							children = append(children, *child)
+						}
+						return true
+					}
+				}
+
 				child := newEntry(e, childPluginEntry)
 				if e.SchemaKnown() {
 					childSchema := e.Schema.GetChild(child.TypeID)
@@ -86,6 +104,9 @@ func (w *walkerImpl) walk(ctx context.Context, e *Entry, depth int) ([]Entry, er
 				children = append(children, child)
 				return true
 			})
+			if followErr != nil {
+				return nil, followErr
+			}
 			// Sort the children by cname to ensure consistent ordering
 			sort.Slice(children, func(i, j int) bool {
 				return children[i].CName < children[j].CName
@@ -123,3 +144,69 @@ func (w *walkerImpl) visit(ctx context.Context, e *Entry, depth int) (bool, erro
 	}
 	return w.q.EvalEntry((*e)), nil
 }
+
+// maxLinkHops is the number of links followed to find an entry before giving up, like the
+// kernel's ELOOP limit for symlinks.
+const maxLinkHops = 40
+
+// follow returns an entry for the link that's the linked entry with the link's path, or nil if
+// the linked entry's pruned. It returns false if the link shouldn't be followed because it's
+// broken or points back to one of its ancestors, so the link's walked like any other entry.
+func (w *walkerImpl) follow(ctx context.Context, parent *Entry, l plugin.Linkable) (*Entry, bool, error) {
+	if w.opts.registry == nil {
+		return nil, false, fmt.Errorf("could not follow links: the plugin registry was not provided")
+	}
+
+	var target plugin.Entry = l
+	for hops := 0; ; hops++ {
+		link, ok := target.(plugin.Linkable)
+		if !ok {
+			break
+		}
+		if hops == maxLinkHops {
+			activity.Record(ctx, "RQL: Not following %v because there are too many levels of links", plugin.ID(l))
+			return nil, false, nil
+		}
+		var err error
+		target, err = plugin.FindLinkTarget(ctx, w.opts.registry, link)
+		if err != nil {
+			activity.Record(ctx, "RQL: Not following broken link %v: %v", plugin.ID(l), err)
+			return nil, false, nil
+		}
+	}
+
+	targetID := plugin.ID(target)
+	isAncestor := func(id string) bool {
+		return id == targetID || strings.HasPrefix(id, strings.TrimSuffix(targetID, "/")+"/")
+	}
+	if isAncestor(plugin.ID(l)) {
+		activity.Record(ctx, "RQL: Not following %v because it links to its ancestor %v", plugin.ID(l), targetID)
+		return nil, false, nil
+	}
+	for cur := parent; cur != nil; cur = cur.parent {
+		if plugin.ID(cur.pluginEntry) == targetID {
+			activity.Record(ctx, "RQL: Not following %v because it links to its ancestor %v", plugin.ID(l), targetID)
+			return nil, false, nil
+		}
+	}
+
+	child := newEntry(parent, target)
+	child.Name = plugin.Name(l)
+	child.CName = plugin.CName(l)
+	child.Path = newEntry(parent, l).Path
+	if parent.SchemaKnown() {
+		// The linked entry's schema isn't part of the link's parent's schema, so it's pruned
+		// separately.
+		s, err := plugin.Schema(target)
+		if err != nil {
+			return nil, false, err
+		}
+		if s == nil {
+			child.Schema = nil
+		} else if child.Schema = prune(newEntrySchema(s), w.q, w.opts); child.Schema == nil {
+			// Neither the linked entry nor its descendants satisfy the query.
+			return nil, true, nil
+		}
+	}
+	return &child, true, nil
+}
@@ -178,6 +178,56 @@ func (s *WalkerTestSuite) TestWalk_VisitErrors() {
 	s.Regexp(`full.*metadata.*failed.*metadata`, err)
 }
 
+func (s *WalkerTestSuite) TestWalk_Follow() {
+	tree := s.setupDefaultMocksForWalk()
+
+	// The link's target is in the "mine" plugin. It has a link back to itself, which isn't
+	// followed so that the walk ends.
+	target := s.toPluginEntry("/mine/target", true, "")
+	back := plugin.NewLink("back", "/mine/target")
+	back.SetTestID("/mine/target/back")
+	s.mockList(target, false, []plugin.Entry{back}, nil)
+	registry := plugin.NewRegistry()
+	s.NoError(registry.RegisterPlugin(&mockRoot{EntryBase: plugin.NewEntry("mine"), children: []plugin.Entry{target}}, nil))
+
+	// Link targets are resolved against absolute IDs, like the ones Wash's entries have.
+	link := plugin.NewLink("link", "/mine/target")
+	tree["."].SetTestID("/")
+	tree["./foo/baz"].isNotParent = false
+	s.mockList(tree["./foo/baz"], false, []plugin.Entry{link}, nil)
+
+	s.walker.opts.FollowLinks(registry)
+	entries := s.mustWalk(context.Background(), tree["."])
+	s.assertEntries(
+		[]string{
+			"foo",
+			"foo/bar",
+			"foo/bar/1",
+			"foo/bar/2",
+			"foo/baz",
+			"foo/baz/link",
+			"foo/baz/link/back",
+		},
+		entries,
+		nil,
+	)
+	s.Empty(entries[5].LinkTarget, "followed links are walked as the linked entry")
+	s.Equal("/mine/target", plugin.ID(entries[5].pluginEntry))
+}
+
+func (s *WalkerTestSuite) TestWalk_FollowBrokenLink() {
+	tree := s.setupDefaultMocksForWalk()
+	link := plugin.NewLink("link", "/mine/missing")
+	tree["."].SetTestID("/")
+	tree["./foo/baz"].isNotParent = false
+	s.mockList(tree["./foo/baz"], false, []plugin.Entry{link}, nil)
+
+	s.walker.opts.FollowLinks(plugin.NewRegistry())
+	entries := s.mustWalk(context.Background(), tree["."])
+	s.Equal("foo/baz/link", entries[5].Path)
+	s.NotEmpty(entries[5].LinkTarget)
+}
+
 func (s *WalkerTestSuite) TestVisit_MindepthSet() {
 	s.walker.opts.Mindepth = 1
 	e := newMockEntryForVisit()
@@ -418,3 +468,24 @@ func (m *mockPluginEntry) BlockRead(ctx context.Context, size int64, offset int6
 }
 
 var _ = plugin.Parent(&mockPluginEntry{})
+
+type mockRoot struct {
+	plugin.EntryBase
+	children []plugin.Entry
+}
+
+func (m *mockRoot) Init(map[string]interface{}) error {
+	return nil
+}
+
+func (m *mockRoot) Schema() *plugin.EntrySchema {
+	return nil
+}
+
+func (m *mockRoot) ChildSchemas() []*plugin.EntrySchema {
+	return nil
+}
+
+func (m *mockRoot) List(context.Context) ([]plugin.Entry, error) {
+	return m.children, nil
+}
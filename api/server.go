@@ -25,7 +25,7 @@ const (
 	mountpointKey
 )
 
-// swagger:parameters cacheDelete listEntries entryInfo getMetadata readContent streamUpdates deleteEntry signalEntry entrySchema
+// swagger:parameters cacheDelete listEntries entryInfo getMetadata readContent streamUpdates deleteEntry signalEntry forwardPorts createEntry entrySchema
 //nolint:deadcode,unused
 type params struct {
 	// uniquely identifies an entry
@@ -134,6 +134,8 @@ func StartAPI(
 	r.Handle("/fs/schema", schemaHandler).Methods(http.MethodGet)
 	r.Handle("/fs/delete", deleteHandler).Methods(http.MethodDelete)
 	r.Handle("/fs/signal", signalHandler).Methods(http.MethodPost)
+	r.Handle("/fs/forward", forwardHandler).Methods(http.MethodPost)
+	r.Handle("/fs/create", createHandler).Methods(http.MethodPost)
 	r.Handle("/cache", cacheHandler).Methods(http.MethodDelete)
 	r.Handle("/history", historyHandler).Methods(http.MethodGet)
 	r.Handle("/history/{index:[0-9]+}", historyEntryHandler).Methods(http.MethodGet)
@@ -0,0 +1,11 @@
+package apitypes
+
+// CreateBody encapsulates the payload for a call to a plugin's Create or CreateDir function
+type CreateBody struct {
+	// Name of the child that's to be created
+	Name string `json:"name"`
+	// Initial content of the child. It's ignored when creating a directory.
+	Content []byte `json:"content"`
+	// Dir creates an empty directory instead of a file
+	Dir bool `json:"dir"`
+}
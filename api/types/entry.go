@@ -13,10 +13,13 @@ type Entry struct {
 	CName      string                 `json:"cname"`
 	Attributes plugin.EntryAttributes `json:"attributes"`
 	Metadata   plugin.JSONObject      `json:"metadata"`
+	// LinkTarget is set for links. It's the path of the linked entry relative to
+	// the link's parent, like what readlink returns.
+	LinkTarget string `json:"link_target,omitempty"`
 }
 
 func NewEntry(e plugin.Entry) Entry {
-	return Entry{
+	entry := Entry{
 		TypeID:     plugin.TypeID(e),
 		Name:       plugin.Name(e),
 		CName:      plugin.CName(e),
@@ -24,6 +27,10 @@ func NewEntry(e plugin.Entry) Entry {
 		Attributes: plugin.Attributes(e),
 		Metadata:   plugin.PartialMetadata(e),
 	}
+	if l, ok := e.(plugin.Linkable); ok {
+		entry.LinkTarget = plugin.RelativeLinkTarget(l)
+	}
+	return entry
 }
 
 // Supports returns true if e supports the given action, false
@@ -0,0 +1,20 @@
+package apitypes
+
+import "time"
+
+// ForwardBody encapsulates the payload for a call to a plugin's Forward function
+type ForwardBody struct {
+	// Port mappings of the form <local_port>:<remote_port> or <port>
+	Ports []string `json:"ports"`
+}
+
+// ForwardPacket is a single packet of results from a forward. Data is a status message
+// written by the plugin. If forwarding stops because of an error, the last packet's Err
+// is set.
+//
+// swagger:response
+type ForwardPacket struct {
+	Timestamp time.Time `json:"timestamp"`
+	Data      string    `json:"data,omitempty"`
+	Err       *ErrorObj `json:"error,omitempty"`
+}
@@ -218,6 +218,22 @@ func stringifySupportedActions(path string, entry apitypes.Entry) string {
 				fmt.Sprintf("- wps %s", path),
 				fmt.Sprintf("    Displays all running processes on the given node"),
 			}
+		case plugin.CreateAction().Name:
+			actionDescriptionLines = []string{
+				fmt.Sprintf("- echo 'foo' > %s/<name>", path),
+				fmt.Sprintf("    Creates the child <name> with the content 'foo'"),
+				fmt.Sprintf("- touch %s/<name>", path),
+				fmt.Sprintf("- (anything else that creates files [e.g. 'cp'])"),
+			}
+		case plugin.MkdirAction().Name:
+			actionDescriptionLines = []string{
+				fmt.Sprintf("- mkdir %s/<name>", path),
+			}
+		case plugin.RenameAction().Name:
+			actionDescriptionLines = []string{
+				fmt.Sprintf("- mv %s <new_path>", path),
+				fmt.Sprintf("    Renames the entry, or moves it to another directory that supports it"),
+			}
 		case plugin.DeleteAction().Name:
 			actionDescriptionLines = []string{
 				fmt.Sprintf("- delete %s", path),
@@ -227,6 +243,11 @@ func stringifySupportedActions(path string, entry apitypes.Entry) string {
 				fmt.Sprintf("- signal <signal> %s", path),
 				fmt.Sprintf("    e.g. signal start %s", path),
 			}
+		case plugin.ForwardAction().Name:
+			actionDescriptionLines = []string{
+				fmt.Sprintf("- forward %s <local_port>:<remote_port>...", path),
+				fmt.Sprintf("    e.g. forward %s 8080:80", path),
+			}
 		}
 		for _, line := range actionDescriptionLines {
 			supportedActions.WriteString(fmt.Sprintf("    %v\n", line))
@@ -62,6 +62,10 @@ func (suite *DocsTestSuite) TestStringifySupportedActions() {
 			"exec",
 			"delete",
 			"signal",
+			"forward",
+			"create",
+			"mkdir",
+			"rename",
 		},
 	}
 
@@ -74,6 +78,10 @@ func (suite *DocsTestSuite) TestStringifySupportedActions() {
 	suite.Regexp(`exec.*\n.*wexec foo <command> <args\.\.\.>.*\n.*wexec foo uname`, supportedActions)
 	suite.Regexp("delete.*\n.*delete foo", supportedActions)
 	suite.Regexp("signal.*\n.*signal <signal> foo.*\n.*signal start foo", supportedActions)
+	suite.Regexp("create.*\n.*echo 'foo' > foo/<name>", supportedActions)
+	suite.Regexp("mkdir.*\n.*mkdir foo/<name>", supportedActions)
+	suite.Regexp("rename.*\n.*mv foo <new_path>", supportedActions)
+	suite.Regexp("forward.*\n.*forward foo <local_port>:<remote_port>.*\n.*forward foo 8080:80", supportedActions)
 
 	// Test non-file-like entry
 	entry.Actions = []string{"read", "write"}
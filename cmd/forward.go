@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Benchkram/errz"
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/puppetlabs/wash/cmd/util"
+)
+
+func forwardCommand() *cobra.Command {
+	forwardCmd := &cobra.Command{
+		Use:   "forward <path> <local_port>:<remote_port>...",
+		Short: "Forwards local ports to the entry at the specified path",
+		Long: `Listens on each of the local ports and forwards connections to the corresponding
+remote port on the entry. A mapping of <port> uses the same local and remote port;
+a mapping of :<remote_port> picks a random local port. Forwarding continues until
+you enter Ctrl-C.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: toRunE(forwardMain),
+	}
+
+	return forwardCmd
+}
+
+func forwardMain(cmd *cobra.Command, args []string) exitCode {
+	path := args[0]
+	ports := args[1:]
+
+	conn := cmdutil.NewClient()
+	packets, closer, err := conn.Forward(path, ports)
+	if err != nil {
+		cmdutil.ErrPrintf("%v\n", err)
+		return exitCode{1}
+	}
+	defer func() { errz.Log(closer.Close()) }()
+
+	// Closing the response stops forwarding on the server.
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	stoppedCh := make(chan struct{})
+	go func() {
+		<-sigCh
+		close(stoppedCh)
+		errz.Log(closer.Close())
+	}()
+
+	// Errors that happen once forwarding has started are reported in the last packet.
+	forwardErrored := false
+	for pkt := range packets {
+		if pkt.Err != nil {
+			select {
+			case <-stoppedCh:
+				// Reading errors because we closed the response.
+			default:
+				forwardErrored = true
+				cmdutil.ErrPrintf("%v\n", pkt.Err)
+			}
+			continue
+		}
+		if _, err := io.WriteString(cmdutil.Stdout, pkt.Data); err != nil {
+			cmdutil.ErrPrintf("%v\n", err)
+			return exitCode{1}
+		}
+	}
+	if forwardErrored {
+		return exitCode{1}
+	}
+	return exitCode{0}
+}
@@ -85,3 +85,15 @@ func (c *MockClient) Signal(path string, signal string) error {
 	args := c.Called(path, signal)
 	return args.Error(0)
 }
+
+// Forward mocks Client#Forward
+func (c *MockClient) Forward(path string, ports []string) (<-chan apitypes.ForwardPacket, io.Closer, error) {
+	args := c.Called(path, ports)
+	return args.Get(0).(<-chan apitypes.ForwardPacket), args.Get(1).(io.Closer), args.Error(2)
+}
+
+// Create mocks Client#Create
+func (c *MockClient) Create(path string, body apitypes.CreateBody) (apitypes.Entry, error) {
+	args := c.Called(path, body)
+	return args.Get(0).(apitypes.Entry), args.Error(1)
+}
@@ -131,6 +131,11 @@ func (s *ParseOptionsTestSuite) TestParseOptionsValidOptions() {
 	s.RTC("-mindepth 5 -true", o, "-true")
 	s.RTC("-mindepth 5 -a", o, "-a")
 	s.RTC("-mindepth 5 foo bar baz", o, "foo bar baz")
+
+	o = types.NewOptions()
+	o.Follow = true
+	o.MarkAsSet(types.FollowFlag)
+	s.RTC("-L -true", o, "-true")
 }
 
 func (s *ParseOptionsTestSuite) TestParseOptionsNegativeMaxdepth() {
@@ -14,6 +14,7 @@ type Options struct {
 	Mindepth uint
 	Daystart bool
 	Fullmeta bool
+	Follow   bool
 	Help     HelpOption
 	setFlags map[string]struct{}
 }
@@ -32,6 +33,7 @@ func NewOptions() Options {
 		Maxdepth: DefaultMaxdepth,
 		Daystart: false,
 		Fullmeta: false,
+		Follow:   false,
 		setFlags: make(map[string]struct{}),
 	}
 }
@@ -47,6 +49,8 @@ const (
 	DaystartFlag = "daystart"
 	// FullmetaFlag is the name of the fullmeta option's flag
 	FullmetaFlag = "fullmeta"
+	// FollowFlag is the name of the follow option's flag
+	FollowFlag = "L"
 )
 
 // IsSet returns true if the flag was set, false otherwise.
@@ -71,6 +75,7 @@ func (opts *Options) FlagSet() *flag.FlagSet {
 	fs.IntVar(&opts.Maxdepth, MaxdepthFlag, opts.Maxdepth, "")
 	fs.BoolVar(&opts.Daystart, DaystartFlag, opts.Daystart, "")
 	fs.BoolVar(&opts.Fullmeta, FullmetaFlag, opts.Fullmeta, "")
+	fs.BoolVar(&opts.Follow, FollowFlag, opts.Follow, "")
 	return fs
 }
 
@@ -84,6 +89,7 @@ func OptionsTable() *cmdutil.Table {
 		[]string{"      -maxdepth depth",  "Do not print entries at levels greater than depth (default infinity)"},
 		[]string{"      -daystart",        "Set the reference time to the start of the current day (default false)"},
 		[]string{"      -fullmeta",        "Use the entry's full metadata in meta primary predicates (default false)"},
+		[]string{"      -L",               "Follow links, treating them as the entries they link to (default false)"},
 		[]string{"  -h, -help",            "Print this usage"},
 		[]string{"  -h, -help <primary>",  "Print a detailed description of the specified primary (e.g. \"-help meta\")"},
 		[]string{"  -h, -help syntax",     "Print a detailed description of find's expression syntax"},
@@ -1,6 +1,9 @@
 package find
 
 import (
+	"path/filepath"
+	"strings"
+
 	"github.com/puppetlabs/wash/api/client"
 	"github.com/puppetlabs/wash/cmd/internal/find/parser"
 	"github.com/puppetlabs/wash/cmd/internal/find/primary"
@@ -50,10 +53,12 @@ func (w *walkerImpl) Walk(path string) bool {
 		// true here.
 		return true
 	}
-	return w.walk(e, 0)
+	return w.walk(e, 0, nil)
 }
 
-func (w *walkerImpl) walk(e types.Entry, depth uint) bool {
+// ancestors are the paths of e's ancestors, used to avoid following links that would
+// walk forever.
+func (w *walkerImpl) walk(e types.Entry, depth uint, ancestors []string) bool {
 	// If the Depth option is set, then we visit e after visiting its children.
 	// Otherwise, we visit e first.
 	successful := true
@@ -77,12 +82,23 @@ func (w *walkerImpl) walk(e types.Entry, depth uint) bool {
 			cmdutil.ErrPrintf("could not get children of %v: %v\n", e.NormalizedPath, err)
 			successful = false
 		} else {
+			ancestors = append(ancestors, e.Path)
 			for _, child := range children {
+				if w.opts.Follow && child.LinkTarget != "" {
+					if target, ok := w.follow(child, ancestors); ok {
+						if target.SchemaKnown && target.Schema == nil {
+							// Neither the linked entry nor its descendants satisfy the predicate
+							continue
+						}
+						check(w.walk(target, childDepth, ancestors))
+						continue
+					}
+				}
 				if e.SchemaKnown {
 					// Note that e.Schema != nil here
 					child.SetSchema(e.Schema.GetChild(child.TypeID))
 				}
-				check(w.walk(child, childDepth))
+				check(w.walk(child, childDepth, ancestors))
 			}
 		}
 	}
@@ -128,3 +144,49 @@ func (w *walkerImpl) visit(e types.Entry, depth uint) bool {
 	}
 	return true
 }
+
+// maxLinkHops is the number of links followed to find an entry before giving up, like the
+// kernel's ELOOP limit for symlinks.
+const maxLinkHops = 40
+
+// follow returns the entry that the link links to, with the link's normalized path so that
+// it's printed like the link. It returns false if the link isn't followed because it's broken
+// or it links to one of its ancestors, in which case the link's walked like any other entry.
+func (w *walkerImpl) follow(link types.Entry, ancestors []string) (types.Entry, bool) {
+	target := link
+	for hops := 0; target.LinkTarget != ""; hops++ {
+		if hops == maxLinkHops {
+			cmdutil.ErrPrintf("not following %v: too many levels of links\n", link.NormalizedPath)
+			return link, false
+		}
+		e, err := info(w.conn, filepath.Join(filepath.Dir(target.Path), target.LinkTarget))
+		if err != nil {
+			cmdutil.ErrPrintf("not following broken link %v: %v\n", link.NormalizedPath, err)
+			return link, false
+		}
+		target = e
+	}
+
+	isAncestor := strings.HasPrefix(link.Path, target.Path+"/")
+	for _, ancestor := range ancestors {
+		isAncestor = isAncestor || ancestor == target.Path
+	}
+	if isAncestor {
+		cmdutil.ErrPrintf("not following %v: it links to its ancestor %v\n", link.NormalizedPath, target.Path)
+		return link, false
+	}
+	target.NormalizedPath = link.NormalizedPath
+
+	s, err := w.conn.Schema(target.Path)
+	if err != nil {
+		cmdutil.ErrPrintf("could not get the schema of %v: %v\n", target.Path, err)
+		return link, false
+	}
+	if s != nil {
+		target.SetSchema(types.Prune(s, w.p.SchemaP(), w.opts))
+	} else if w.p.SchemaRequired() {
+		// Like Walk, skip entries without schemas when the predicate requires them.
+		target.SetSchema(nil)
+	}
+	return target, true
+}
@@ -38,6 +38,8 @@ type Opts struct {
 	// LogLevel can be "warn", "info", "debug", or "trace".
 	LogLevel     string
 	PluginConfig map[string]map[string]interface{}
+	FuseOpts     fuse.Opts
+	ContentOpts  plugin.ContentOpts
 }
 
 // SetupLogging configures log level and output file according to configured options.
@@ -119,6 +121,7 @@ func (s *Server) Start() (bool, error) {
 			return successfullyLoadedPlugins, fmt.Errorf("no plugins loaded. If you're planning on using Wash just for its external plugins, then go to https://puppetlabs.github.io/wash/docs/external-plugins")
 		}
 
+		plugin.SetContentOpts(s.opts.ContentOpts)
 		plugin.InitCache()
 
 		analyticsConfig, err := analytics.GetConfig()
@@ -143,6 +146,7 @@ func (s *Server) Start() (bool, error) {
 		registry,
 		s.mountpoint,
 		s.analyticsClient,
+		s.opts.FuseOpts,
 	)
 	if err != nil {
 		s.stopAPIServer()
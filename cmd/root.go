@@ -166,6 +166,7 @@ then starts your system shell with shortcuts configured for wash subcommands.`,
 	addCommand(rootCmd, docsCommand())
 	addCommand(rootCmd, deleteCommand())
 	addCommand(rootCmd, signalCommand())
+	addCommand(rootCmd, forwardCommand())
 
 	return rootCmd
 }
@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/puppetlabs/wash/cmd/internal/config"
 	"github.com/puppetlabs/wash/cmd/internal/server"
 	cmdutil "github.com/puppetlabs/wash/cmd/util"
+	"github.com/puppetlabs/wash/fuse"
 	"github.com/puppetlabs/wash/plugin"
 	"github.com/puppetlabs/wash/plugin/external"
 	"gopkg.in/yaml.v2"
@@ -177,15 +179,93 @@ func serverOptsFor(cmd *cobra.Command) (map[string]plugin.Root, server.Opts, err
 		pluginConfig["local"] = map[string]interface{}{"basepath": localfsPath}
 	}
 
+	fuseOpts, err := fuseOptsFromConfig()
+	if err != nil {
+		return nil, server.Opts{}, err
+	}
+
 	// Return the options
 	return plugins, server.Opts{
 		CPUProfilePath: viper.GetString("cpuprofile"),
 		LogFile:        viper.GetString("logfile"),
 		LogLevel:       viper.GetString("loglevel"),
 		PluginConfig:   pluginConfig,
+		FuseOpts:       fuseOpts,
+		ContentOpts:    contentOptsFromConfig(),
 	}, nil
 }
 
+// defaultMaxCachedContentSize is the default for cache.max_content_size, in bytes.
+const defaultMaxCachedContentSize = 64 << 20
+
+// contentOptsFromConfig returns the plugin.ContentOpts set by the "cache" config keys.
+func contentOptsFromConfig() plugin.ContentOpts {
+	opts := plugin.ContentOpts{
+		MaxCachedSize: defaultMaxCachedContentSize,
+		SpillDir:      viper.GetString("cache.spill_dir"),
+	}
+	if viper.IsSet("cache.max_content_size") {
+		// This accepts sizes like 512KB or 1GB.
+		opts.MaxCachedSize = uint64(viper.GetSizeInBytes("cache.max_content_size"))
+	}
+	return opts
+}
+
+// defaultMaxWriteBufferSize is the default for fuse.max_write_buffer_size, in bytes.
+const defaultMaxWriteBufferSize = 64 << 20
+
+// fuseOptsFromConfig returns the fuse.Opts set by the "fuse" config keys.
+func fuseOptsFromConfig() (fuse.Opts, error) {
+	opts := fuse.Opts{
+		DisableDelete: viper.GetBool("fuse.disable_delete"),
+		ReadOnly:      viper.GetBool("fuse.read_only"),
+		AllowOther:    viper.GetBool("fuse.allow_other"),
+		Name:          viper.GetString("fuse.name"),
+		Subtype:       viper.GetString("fuse.subtype"),
+
+		MaxWriteBufferSize: defaultMaxWriteBufferSize,
+		WriteBufferDir:     viper.GetString("fuse.write_buffer_dir"),
+		IgnoredNames:       viper.GetStringSlice("fuse.ignored_names"),
+
+		DefaultTimeout:    viper.GetDuration("fuse.timeout"),
+		KeepTimedOutCalls: viper.GetBool("fuse.keep_timed_out_calls"),
+	}
+	if timeouts := viper.GetStringMapString("fuse.timeouts"); len(timeouts) > 0 {
+		opts.Timeouts = make(map[string]time.Duration, len(timeouts))
+		for op, timeout := range timeouts {
+			duration, err := time.ParseDuration(timeout)
+			if err != nil {
+				return fuse.Opts{}, fmt.Errorf("fuse.timeouts.%v must be a duration like 30s: %v", op, err)
+			}
+			opts.Timeouts[op] = duration
+		}
+	}
+	if viper.IsSet("fuse.max_write_buffer_size") {
+		opts.MaxWriteBufferSize = uint64(viper.GetSizeInBytes("fuse.max_write_buffer_size"))
+	}
+	if viper.IsSet("fuse.uid") {
+		uid := viper.GetUint32("fuse.uid")
+		opts.UID = &uid
+	}
+	if viper.IsSet("fuse.gid") {
+		gid := viper.GetUint32("fuse.gid")
+		opts.GID = &gid
+	}
+	// YAML reads a umask like 022 as an octal number, but environment variables are strings.
+	switch umask := viper.Get("fuse.umask").(type) {
+	case nil:
+	case string:
+		mask, err := strconv.ParseUint(umask, 8, 32)
+		if err != nil {
+			return fuse.Opts{}, fmt.Errorf("fuse.umask must be an octal number like 022: %v", err)
+		}
+		opts.Umask = os.FileMode(mask)
+	default:
+		opts.Umask = os.FileMode(viper.GetUint32("fuse.umask"))
+	}
+	return opts, nil
+}
+
 func promptEnabledPlugins() (map[string]plugin.Root, error) {
 	// Prompt them for the list of enabled plugins. This should look something
 	// like
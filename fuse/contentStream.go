@@ -0,0 +1,73 @@
+package fuse
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// contentStream reads a SequentialReadable entry's content for an open handle. Reads that
+// continue from where the last one ended, which is how most programs read files, keep reading
+// the same stream. Reads past that skip ahead, and reads before it start a new stream. Its
+// mux serializes the handle's reads, which can block, so they don't hold the file's lock.
+type contentStream struct {
+	mux    sync.Mutex
+	entry  plugin.SequentialReadable
+	reader io.ReadCloser
+	cancel context.CancelFunc
+	offset int64
+}
+
+func newContentStream(entry plugin.SequentialReadable) *contentStream {
+	return &contentStream{entry: entry}
+}
+
+func (s *contentStream) read(ctx context.Context, size int64, offset int64) ([]byte, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.reader != nil && offset < s.offset {
+		activity.Record(ctx, "FUSE: Restarting stream of %v to read from %v", plugin.ID(s.entry), offset)
+		s.closeReader(ctx)
+	}
+	if s.reader == nil {
+		// The stream outlives the request that starts it, so it gets its own context.
+		streamCtx, cancel := context.WithCancel(detachedContext(ctx))
+		reader, err := plugin.ReadStreamWithAnalytics(streamCtx, s.entry)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		s.reader, s.cancel, s.offset = reader, cancel, 0
+	}
+
+	data, err := plugin.ReadFromStream(s.reader, size, offset-s.offset)
+	if err != nil && err != io.EOF {
+		// We don't know where the stream stopped, so start a new one next time.
+		s.closeReader(ctx)
+		return nil, err
+	}
+	s.offset = offset + int64(len(data))
+	return data, err
+}
+
+func (s *contentStream) close(ctx context.Context) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.closeReader(ctx)
+}
+
+// closeReader closes the current stream. The caller must hold mux.
+func (s *contentStream) closeReader(ctx context.Context) {
+	if s.reader == nil {
+		return
+	}
+	if err := s.reader.Close(); err != nil {
+		activity.Warnf(ctx, "FUSE: Closing stream of %v errored: %v", plugin.ID(s.entry), err)
+	}
+	s.cancel()
+	s.reader, s.cancel, s.offset = nil, nil, 0
+}
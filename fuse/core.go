@@ -3,10 +3,14 @@ package fuse
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"os/user"
+	"path"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"bazil.org/fuse"
@@ -19,18 +23,149 @@ import (
 
 var startTime = time.Now()
 
+// Opts configures the FUSE filesystem.
+type Opts struct {
+	// DisableDelete stops rm and rmdir from deleting entries.
+	DisableDelete bool
+	// ReadOnly mounts the filesystem read-only, so entries can't be created, written, renamed
+	// or deleted through it.
+	ReadOnly bool
+	// AllowOther lets users other than the one running the server use the filesystem. The
+	// kernel then checks their access against each file's owner and mode. Linux requires
+	// user_allow_other in /etc/fuse.conf to use it.
+	AllowOther bool
+	// UID and GID own every file. They default to the user running the server.
+	UID, GID *uint32
+	// Umask clears permission bits from every file's mode.
+	Umask os.FileMode
+	// MaxWriteBufferSize is the most data, in bytes, that's buffered in memory for a file
+	// that's being written. Larger data is buffered in a temporary file in WriteBufferDir,
+	// which defaults to the system's temporary directory. 0 buffers all data in memory.
+	MaxWriteBufferSize uint64
+	WriteBufferDir     string
+	// Name and Subtype identify the filesystem in mount's output, which shows it as
+	// "<Name> on <mountpoint> type fuse.<Subtype>". They default to "wash".
+	Name, Subtype string
+	// IgnoredNames are glob patterns, as accepted by path.Match, of names that are never looked
+	// up in plugins. Shells and editors probe for lots of them, like .git or .envrc. Matching
+	// entries are hidden and can't be created through the filesystem.
+	IgnoredNames []string
+	// Timeouts bound how long operations that read from plugins wait for them, keyed by the
+	// operation's name in timeoutOps. Operations that aren't listed wait for DefaultTimeout,
+	// and 0 waits indefinitely. Operations that time out fail with ETIMEDOUT.
+	Timeouts       map[string]time.Duration
+	DefaultTimeout time.Duration
+	// KeepTimedOutCalls lets plugin calls continue after their operation times out so that
+	// their results are cached for the next attempt. Otherwise they're cancelled.
+	KeepTimedOutCalls bool
+}
+
+// timeoutOps are the operations that Opts.Timeouts can bound. Operations that change entries
+// aren't bounded because they could still succeed after we've given up on them.
+var timeoutOps = []string{"lookup", "list", "attr", "open", "read"}
+
+func (o Opts) timeoutOf(op string) time.Duration {
+	if timeout, ok := o.Timeouts[op]; ok {
+		return timeout
+	}
+	return o.DefaultTimeout
+}
+
+func (o Opts) validate() error {
+	if o.DefaultTimeout < 0 {
+		return fmt.Errorf("the default timeout %v is negative", o.DefaultTimeout)
+	}
+	for op, timeout := range o.Timeouts {
+		known := false
+		for _, timeoutOp := range timeoutOps {
+			known = known || op == timeoutOp
+		}
+		if !known {
+			return fmt.Errorf("%v has a timeout, but only %v can", op, strings.Join(timeoutOps, ", "))
+		}
+		if timeout < 0 {
+			return fmt.Errorf("the %v timeout %v is negative", op, timeout)
+		}
+	}
+	for _, pattern := range o.IgnoredNames {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("the ignored name %q is an invalid pattern: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+func (o Opts) isIgnored(name string) bool {
+	for _, pattern := range o.IgnoredNames {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (o Opts) owner() (uint32, uint32) {
+	u, g := uid, gid
+	if o.UID != nil {
+		u = *o.UID
+	}
+	if o.GID != nil {
+		g = *o.GID
+	}
+	return u, g
+}
+
+func (o Opts) mountOptions() []fuse.MountOption {
+	name, subtype := o.Name, o.Subtype
+	if name == "" {
+		name = "wash"
+	}
+	if subtype == "" {
+		subtype = "wash"
+	}
+	mountOpts := []fuse.MountOption{fuse.FSName(name), fuse.Subtype(subtype)}
+	if o.ReadOnly {
+		mountOpts = append(mountOpts, fuse.ReadOnly())
+	}
+	if o.AllowOther {
+		// Without default_permissions, every user would have the same access as the user
+		// running the server.
+		mountOpts = append(mountOpts, fuse.AllowOther(), fuse.DefaultPermissions())
+	}
+	return mountOpts
+}
+
 // Root represents the root of the FUSE filesystem
 type Root struct {
 	registry *plugin.Registry
+	opts     Opts
+	// server is nil when testing nodes without serving them.
+	server *fs.Server
 }
 
-func newRoot(registry *plugin.Registry) Root {
-	return Root{registry: registry}
+func newRoot(registry *plugin.Registry, opts Opts) Root {
+	return Root{registry: registry, opts: opts}
 }
 
 // Root presents the root of the filesystem.
 func (r *Root) Root() (fs.Node, error) {
-	return newDir(nil, r.registry), nil
+	root := newDir(nil, r.registry)
+	root.root = r
+	return root, nil
+}
+
+// invalidateEntry tells the kernel to forget the node it's cached for name in parent, so that
+// it's looked up again. It's asynchronous because the kernel waits for any request on parent
+// that's being served to finish.
+func (r *Root) invalidateEntry(ctx context.Context, parent fs.Node, name string) {
+	if r == nil || r.server == nil {
+		return
+	}
+	go func() {
+		if err := r.server.InvalidateEntry(parent, name); err != nil && err != fuse.ErrNotCached {
+			activity.Warnf(ctx, "FUSE: Invalidating %v in %v errored: %v", name, parent, err)
+		}
+	}()
 }
 
 func getIDs() (uint32, uint32) {
@@ -58,22 +193,73 @@ type fuseNode struct {
 	ftype  string
 	parent *dir
 	entry  plugin.Entry
+	// root is shared by all nodes. It's nil for nodes that aren't descended from a Root.
+	root *Root
 }
 
 func newFuseNode(ftype string, parent *dir, entry plugin.Entry) fuseNode {
-	return fuseNode{
+	node := fuseNode{
 		ftype:  ftype,
 		parent: parent,
 		entry:  entry,
 	}
+	if parent != nil {
+		node.root = parent.root
+	}
+	return node
 }
 
 func (f *fuseNode) String() string {
 	return plugin.ID(f.entry)
 }
 
+func (f *fuseNode) options() Opts {
+	if f.root == nil {
+		return Opts{}
+	}
+	return f.root.opts
+}
+
+// checkWritable rejects changes to a read-only filesystem. The kernel rejects most of them
+// before they reach us, but not all (such as writes to files that were opened before a remount).
+func (f *fuseNode) checkWritable(ctx context.Context, op string) error {
+	if f.options().ReadOnly {
+		activity.Warnf(ctx, "FUSE: %v %v rejected by read-only mount", op, f)
+		return syscall.EROFS
+	}
+	return nil
+}
+
+// toErrno converts errors returned by plugins to an errno so that they're reported sensibly by
+// the commands that use the filesystem. FUSE reports any other errors as EIO.
+func toErrno(err error) error {
+	var errno syscall.Errno
+	switch {
+	case errors.As(err, &errno):
+		return errno
+	case errors.As(err, &plugin.InvalidInputErr{}):
+		return syscall.EINVAL
+	case errors.Is(err, plugin.ErrCrossParentRename):
+		return syscall.EXDEV
+	case errors.Is(err, os.ErrNotExist):
+		return syscall.ENOENT
+	case errors.Is(err, os.ErrExist):
+		return syscall.EEXIST
+	case errors.Is(err, os.ErrPermission):
+		return syscall.EACCES
+	case errors.Is(err, context.DeadlineExceeded):
+		return syscall.ETIMEDOUT
+	case errors.Is(err, context.Canceled):
+		return syscall.EINTR
+	default:
+		return err
+	}
+}
+
 // Applies attributes where non-default, and sets defaults otherwise.
-func applyAttr(a *fuse.Attr, attr plugin.EntryAttributes, defaultMode os.FileMode) {
+func (f *fuseNode) applyAttr(a *fuse.Attr, attr plugin.EntryAttributes, defaultMode os.FileMode) {
+	opts := f.options()
+
 	// Setting a.Valid to 1 second avoids frequent Attr calls.
 	a.Valid = 1 * time.Second
 
@@ -90,6 +276,7 @@ func applyAttr(a *fuse.Attr, attr plugin.EntryAttributes, defaultMode os.FileMod
 	} else {
 		a.Mode = defaultMode
 	}
+	a.Mode &^= opts.Umask & os.ModePerm
 
 	if attr.HasSize() {
 		a.Size = attr.Size()
@@ -112,8 +299,7 @@ func applyAttr(a *fuse.Attr, attr plugin.EntryAttributes, defaultMode os.FileMod
 		a.Crtime = attr.Crtime()
 	}
 	a.BlockSize = 4096
-	a.Uid = uid
-	a.Gid = gid
+	a.Uid, a.Gid = opts.owner()
 }
 
 // Re-discovers the source ancestor of the current node to get fresh data. It returns that ancestor
@@ -141,6 +327,85 @@ func (f *fuseNode) refind(ctx context.Context) (plugin.Entry, error) {
 	return plugin.FindEntry(ctx, parent, segments)
 }
 
+// refindWithTimeout refinds the current entry within the op's timeout.
+func (f *fuseNode) refindWithTimeout(ctx context.Context, op string) (plugin.Entry, error) {
+	parent, segments := f.getSource()
+	if parent == nil {
+		return f.entry, nil
+	}
+	entry, err := f.withTimeout(ctx, op, func(ctx context.Context) (interface{}, error) {
+		return plugin.FindEntry(ctx, parent, segments)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry.(plugin.Entry), nil
+}
+
+// withTimeout calls fn, which calls plugins for the op, and waits for its result until the op's
+// timeout expires. Then it returns ETIMEDOUT rather than leaving the calling process hanging on a
+// plugin that doesn't stop when it's cancelled. It also returns EINTR as soon as the request's
+// interrupted. fn may outlive the request, so it should only read state that no other request
+// changes and report everything it finds through its result.
+func (f *fuseNode) withTimeout(ctx context.Context, op string, fn func(context.Context) (interface{}, error)) (interface{}, error) {
+	opts := f.options()
+	timeout := opts.timeoutOf(op)
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+
+	var fnCtx context.Context
+	var cancel context.CancelFunc
+	if opts.KeepTimedOutCalls {
+		fnCtx, cancel = context.WithCancel(detachedContext(ctx))
+	} else {
+		fnCtx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	type result struct {
+		value interface{}
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer cancel()
+		value, err := fn(fnCtx)
+		done <- result{value, err}
+	}()
+
+	timedOut := func() (interface{}, error) {
+		activity.Warnf(ctx, "FUSE: %v %v timed out after %v waiting for the %v plugin", op, f, timeout, f.pluginName())
+		return nil, syscall.ETIMEDOUT
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case res := <-done:
+		// Plugins that honor fnCtx's deadline can return before the timer fires.
+		if errors.Is(res.err, context.DeadlineExceeded) && fnCtx.Err() == context.DeadlineExceeded {
+			return timedOut()
+		}
+		return res.value, res.err
+	case <-timer.C:
+		return timedOut()
+	case <-ctx.Done():
+		log.Debugf("FUSE: %v %v interrupted", op, f)
+		return nil, syscall.EINTR
+	}
+}
+
+// pluginName returns the name of the plugin that the node's entry comes from.
+func (f *fuseNode) pluginName() string {
+	return strings.SplitN(strings.TrimPrefix(plugin.ID(f.entry), "/"), "/", 2)[0]
+}
+
+// detachedContext returns a context for plugin calls that outlive the request that starts them.
+// FUSE cancels a request's context when the request's done, so it isn't derived from ctx, but it
+// keeps ctx's journal and analytics client so that the calls are still recorded.
+func detachedContext(ctx context.Context) context.Context {
+	newctx := context.WithValue(context.Background(), activity.JournalKey, ctx.Value(activity.JournalKey))
+	return context.WithValue(newctx, analytics.ClientKey, ctx.Value(analytics.ClientKey))
+}
+
 // ServeFuseFS starts serving a fuse filesystem that lists the registered plugins.
 // It returns three values:
 //   1. A channel to initiate the shutdown (stopCh).
@@ -152,13 +417,18 @@ func ServeFuseFS(
 	filesys *plugin.Registry,
 	mountpoint string,
 	analyticsClient analytics.Client,
+	opts Opts,
 ) (chan<- context.Context, <-chan struct{}, error) {
 	fuse.Debug = func(msg interface{}) {
 		log.Tracef("FUSE: %v", msg)
 	}
 
+	if err := opts.validate(); err != nil {
+		return nil, nil, err
+	}
+
 	log.Infof("FUSE: Mounting at %v", mountpoint)
-	fuseConn, err := fuse.Mount(mountpoint)
+	fuseConn, err := fuse.Mount(mountpoint, opts.mountOptions()...)
 	if err != nil {
 		return nil, nil, mountFailedErr(err)
 	}
@@ -177,7 +447,8 @@ func ServeFuseFS(
 			},
 		}
 		server := fs.New(fuseConn, serverConfig)
-		root := newRoot(filesys)
+		root := newRoot(filesys, opts)
+		root.server = server
 		if err := server.Serve(&root); err != nil {
 			log.Warnf("FUSE: fs.Serve errored with: %v", err)
 		}
@@ -42,25 +42,62 @@ func (d *dir) children(ctx context.Context) (*plugin.EntryMap, error) {
 	return nil, syscall.ENOENT
 }
 
+// childrenWithTimeout returns d's children within the op's timeout.
+func (d *dir) childrenWithTimeout(ctx context.Context, op string) (*plugin.EntryMap, error) {
+	entries, err := d.withTimeout(ctx, op, func(ctx context.Context) (interface{}, error) {
+		return d.children(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries.(*plugin.EntryMap), nil
+}
+
 // Lookup searches a directory for children.
 func (d *dir) Lookup(ctx context.Context, req *fuse.LookupRequest, resp *fuse.LookupResponse) (fs.Node, error) {
 	// Find is only occasionally useful and happens a lot. Log it to debug like other activity, but
 	// leave it out of activity because it introduces history entries for miscellaneous shell commands.
 	log.Debugf("FUSE: Find %v in %v", req.Name, d)
 
-	entries, err := d.children(ctx)
-	if err != nil {
-		activity.Warnf(ctx, "FUSE: Find %v in %v errored: %v", req.Name, d, err)
+	// The kernel can't cache names that aren't found, so ignored and recently missing names
+	// are rejected here without listing d.
+	cname := req.Name
+	if d.options().isIgnored(cname) {
+		log.Tracef("FUSE: Ignored %v in %v", cname, d)
+		return nil, syscall.ENOENT
+	}
+	parent := d.entry.(plugin.Parent)
+	if plugin.IsMissing(parent, cname) {
+		log.Debugf("FUSE: %v recently not found in %v", cname, d)
 		return nil, syscall.ENOENT
 	}
 
-	cname := req.Name
+	entries, err := d.childrenWithTimeout(ctx, "lookup")
+	if err != nil {
+		if err != syscall.ETIMEDOUT && err != syscall.EINTR {
+			activity.Warnf(ctx, "FUSE: Find %v in %v errored: %v", req.Name, d, err)
+		}
+		return nil, toErrno(err)
+	}
+
 	entry, ok := entries.Load(cname)
 	if !ok {
 		log.Debugf("FUSE: %v not found in %v", req.Name, d)
+		plugin.SetMissing(parent, cname)
 		return nil, syscall.ENOENT
 	}
 
+	// Names come from d's List result, so the kernel can cache them for as long as it is.
+	// Parents whose List isn't cached, like plugin roots, keep the kernel's default.
+	if ttl := plugin.TTLOf(parent, plugin.ListOp); ttl > 0 {
+		resp.EntryValid = ttl
+	}
+
+	if l, ok := entry.(plugin.Linkable); ok {
+		log.Debugf("FUSE: Found link %v/%v", d, cname)
+		return newLink(d, l), nil
+	}
+
 	if plugin.ListAction().IsSupportedOn(entry) {
 		childdir := newDir(d, entry.(plugin.Parent))
 		log.Debugf("FUSE: Found directory %v", childdir)
@@ -75,17 +112,24 @@ func (d *dir) Lookup(ctx context.Context, req *fuse.LookupRequest, resp *fuse.Lo
 func (d *dir) ReadDirAll(ctx context.Context) ([]fuse.Dirent, error) {
 	activity.Record(ctx, "FUSE: List %v", d)
 
-	entries, err := d.children(ctx)
+	entries, err := d.childrenWithTimeout(ctx, "list")
 	if err != nil {
-		activity.Warnf(ctx, "FUSE: List %v errored: %v", d, err)
-		return nil, err
+		if err != syscall.ETIMEDOUT && err != syscall.EINTR {
+			activity.Warnf(ctx, "FUSE: List %v errored: %v", d, err)
+		}
+		return nil, toErrno(err)
 	}
 
 	res := make([]fuse.Dirent, 0, entries.Len())
 	entries.Range(func(cname string, entry plugin.Entry) bool {
+		if d.options().isIgnored(cname) {
+			return true
+		}
 		var de fuse.Dirent
 		de.Name = cname
-		if plugin.ListAction().IsSupportedOn(entry) {
+		if _, ok := entry.(plugin.Linkable); ok {
+			de.Type = fuse.DT_Link
+		} else if plugin.ListAction().IsSupportedOn(entry) {
 			de.Type = fuse.DT_Dir
 		} else {
 			de.Type = fuse.DT_File
@@ -97,11 +141,185 @@ func (d *dir) ReadDirAll(ctx context.Context) ([]fuse.Dirent, error) {
 	return res, nil
 }
 
+var _ = fs.NodeCreater(&dir{})
+
+// Create creates an empty child, then returns it as an open file so that the content is written
+// when the handle's flushed.
+func (d *dir) Create(ctx context.Context, req *fuse.CreateRequest, resp *fuse.CreateResponse) (fs.Node, fs.Handle, error) {
+	activity.Record(ctx, "FUSE: Create %v in %v: %+v", req.Name, d, *req)
+	if err := d.checkWritable(ctx, "Create in"); err != nil {
+		return nil, nil, err
+	}
+	if d.options().isIgnored(req.Name) {
+		activity.Warnf(ctx, "FUSE: Create %v in %v rejected because it's ignored by config", req.Name, d)
+		return nil, nil, syscall.EPERM
+	}
+
+	parent, err := d.refind(ctx)
+	if err != nil {
+		activity.Warnf(ctx, "FUSE: Create errored %v, %v", d, err)
+		return nil, nil, err
+	}
+	creatable, ok := parent.(plugin.Creatable)
+	if !ok {
+		activity.Warnf(ctx, "FUSE: Create unsupported on %v", d)
+		return nil, nil, syscall.ENOTSUP
+	}
+
+	entry, err := plugin.CreateWithAnalytics(ctx, creatable, req.Name, []byte{})
+	if err != nil {
+		activity.Warnf(ctx, "FUSE: Create %v in %v errored: %v", req.Name, d, err)
+		return nil, nil, toErrno(err)
+	}
+
+	f := newFile(d, entry)
+	f.fillAttr(&resp.Attr)
+	activity.Record(ctx, "FUSE: Created %v", f)
+	return f, f, nil
+}
+
+var _ = fs.NodeMkdirer(&dir{})
+
+// Mkdir creates an empty child directory.
+func (d *dir) Mkdir(ctx context.Context, req *fuse.MkdirRequest) (fs.Node, error) {
+	activity.Record(ctx, "FUSE: Mkdir %v in %v", req.Name, d)
+	if err := d.checkWritable(ctx, "Mkdir in"); err != nil {
+		return nil, err
+	}
+	if d.options().isIgnored(req.Name) {
+		activity.Warnf(ctx, "FUSE: Mkdir %v in %v rejected because it's ignored by config", req.Name, d)
+		return nil, syscall.EPERM
+	}
+
+	parent, err := d.refind(ctx)
+	if err != nil {
+		activity.Warnf(ctx, "FUSE: Mkdir errored %v, %v", d, err)
+		return nil, err
+	}
+	dirCreatable, ok := parent.(plugin.DirCreatable)
+	if !ok {
+		activity.Warnf(ctx, "FUSE: Mkdir unsupported on %v", d)
+		return nil, syscall.EPERM
+	}
+
+	entry, err := plugin.CreateDirWithAnalytics(ctx, dirCreatable, req.Name)
+	if err != nil {
+		activity.Warnf(ctx, "FUSE: Mkdir %v in %v errored: %v", req.Name, d, err)
+		return nil, toErrno(err)
+	}
+
+	childdir := newDir(d, entry)
+	activity.Record(ctx, "FUSE: Created directory %v", childdir)
+	return childdir, nil
+}
+
+var _ = fs.NodeRemover(&dir{})
+
+// Remove deletes a child for rm and rmdir. plugin.Delete updates the parent's cached list, so
+// the child disappears from the next listing unless it's only been marked for deletion.
+func (d *dir) Remove(ctx context.Context, req *fuse.RemoveRequest) error {
+	activity.Record(ctx, "FUSE: Remove %v from %v", req.Name, d)
+	if err := d.checkWritable(ctx, "Remove from"); err != nil {
+		return err
+	}
+
+	if d.options().DisableDelete {
+		activity.Warnf(ctx, "FUSE: Remove %v from %v disabled by config", req.Name, d)
+		return syscall.EPERM
+	}
+
+	entries, err := d.children(ctx)
+	if err != nil {
+		activity.Warnf(ctx, "FUSE: Remove %v from %v errored: %v", req.Name, d, err)
+		return toErrno(err)
+	}
+	entry, ok := entries.Load(req.Name)
+	if !ok {
+		return syscall.ENOENT
+	}
+
+	isDir := plugin.ListAction().IsSupportedOn(entry)
+	if req.Dir && !isDir {
+		return syscall.ENOTDIR
+	} else if !req.Dir && isDir {
+		return syscall.EISDIR
+	}
+
+	deletable, ok := entry.(plugin.Deletable)
+	if !ok {
+		activity.Warnf(ctx, "FUSE: Remove unsupported on %v/%v", d, req.Name)
+		return syscall.EPERM
+	}
+
+	deleted, err := plugin.DeleteWithAnalytics(ctx, deletable)
+	if err != nil {
+		activity.Warnf(ctx, "FUSE: Remove %v from %v errored: %v", req.Name, d, err)
+		return toErrno(err)
+	}
+	if deleted {
+		activity.Record(ctx, "FUSE: Removed %v from %v", req.Name, d)
+	} else {
+		activity.Record(ctx, "FUSE: Marked %v in %v for deletion", req.Name, d)
+	}
+	return nil
+}
+
+var _ = fs.NodeRenamer(&dir{})
+
+// Rename renames or moves a child. Moves that the child doesn't support are reported as EXDEV
+// so that mv falls back to copying and deleting it.
+func (d *dir) Rename(ctx context.Context, req *fuse.RenameRequest, newDir fs.Node) error {
+	newParentDir, ok := newDir.(*dir)
+	if !ok {
+		return syscall.EXDEV
+	}
+	activity.Record(ctx, "FUSE: Rename %v/%v to %v/%v", d, req.OldName, newParentDir, req.NewName)
+	if err := d.checkWritable(ctx, "Rename in"); err != nil {
+		return err
+	}
+	if d.options().isIgnored(req.NewName) {
+		activity.Warnf(ctx, "FUSE: Rename to %v rejected because it's ignored by config", req.NewName)
+		return syscall.EPERM
+	}
+
+	entries, err := d.children(ctx)
+	if err != nil {
+		activity.Warnf(ctx, "FUSE: Rename %v in %v errored: %v", req.OldName, d, err)
+		return toErrno(err)
+	}
+	entry, ok := entries.Load(req.OldName)
+	if !ok {
+		return syscall.ENOENT
+	}
+	renamable, ok := entry.(plugin.Renamable)
+	if !ok {
+		activity.Warnf(ctx, "FUSE: Rename unsupported on %v/%v", d, req.OldName)
+		return syscall.EXDEV
+	}
+
+	newParent, err := newParentDir.refind(ctx)
+	if err != nil {
+		activity.Warnf(ctx, "FUSE: Rename errored finding %v: %v", newParentDir, err)
+		return toErrno(err)
+	}
+
+	if _, err := plugin.RenameWithAnalytics(ctx, renamable, newParent.(plugin.Parent), req.NewName); err != nil {
+		activity.Warnf(ctx, "FUSE: Rename %v/%v to %v/%v errored: %v", d, req.OldName, newParentDir, req.NewName, err)
+		return toErrno(err)
+	}
+	activity.Record(ctx, "FUSE: Renamed %v/%v to %v/%v", d, req.OldName, newParentDir, req.NewName)
+
+	// The kernel moves the node it's cached for the old name, but that node still refers to
+	// the entry's old path. Have it look up the moved entry instead.
+	d.root.invalidateEntry(ctx, newParentDir, req.NewName)
+	return nil
+}
+
 func (d *dir) Attr(ctx context.Context, a *fuse.Attr) error {
 	// FUSE caches nodes for a long time, meaning there's a chance that
 	// f's attributes are outdated. 'refind' requests the entry from its
 	// parent to ensure it has updated attributes.
-	entry, err := d.refind(ctx)
+	entry, err := d.refindWithTimeout(ctx, "attr")
 	if err != nil {
 		activity.Warnf(ctx, "FUSE: Attr errored %v, %v", d, err)
 		return err
@@ -111,7 +329,11 @@ func (d *dir) Attr(ctx context.Context, a *fuse.Attr) error {
 	// is not strictly necessary for the other FUSE operations, we choose to
 	// leave it alone.
 
-	applyAttr(a, plugin.Attributes(entry), os.ModeDir|0550)
+	mode := os.ModeDir | 0550
+	if plugin.CreateAction().IsSupportedOn(entry) || plugin.MkdirAction().IsSupportedOn(entry) {
+		mode |= 0220
+	}
+	d.applyAttr(a, plugin.Attributes(entry), mode)
 	// Attr is not a particularly interesting call and happens a lot. Log it to debug like other
 	// activity, but leave it out of activity because it introduces history entries for lots of
 	// miscellaneous shell activity.
@@ -122,3 +344,30 @@ func (d *dir) Attr(ctx context.Context, a *fuse.Attr) error {
 	}
 	return nil
 }
+
+var _ = fs.NodeGetxattrer(&dir{})
+var _ = fs.NodeListxattrer(&dir{})
+
+func (d *dir) Getxattr(ctx context.Context, req *fuse.GetxattrRequest, resp *fuse.GetxattrResponse) error {
+	if !isWashXattr(req.Name) {
+		return fuse.ErrNoXattr
+	}
+
+	// Like Attr, refind the entry so that its metadata is current.
+	entry, err := d.refindWithTimeout(ctx, "attr")
+	if err != nil {
+		activity.Warnf(ctx, "FUSE: Getxattr errored %v, %v", d, err)
+		return toErrno(err)
+	}
+	return getxattr(d, entry, req, resp)
+}
+
+func (d *dir) Listxattr(ctx context.Context, req *fuse.ListxattrRequest, resp *fuse.ListxattrResponse) error {
+	entry, err := d.refindWithTimeout(ctx, "attr")
+	if err != nil {
+		activity.Warnf(ctx, "FUSE: Listxattr errored %v, %v", d, err)
+		return toErrno(err)
+	}
+	listxattr(d, entry, resp)
+	return nil
+}
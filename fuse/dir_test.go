@@ -0,0 +1,311 @@
+package fuse
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"bazil.org/fuse"
+	"github.com/puppetlabs/wash/datastore"
+	"github.com/puppetlabs/wash/plugin"
+	plugintest "github.com/puppetlabs/wash/plugin/test"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type dirTestSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (suite *dirTestSuite) SetupTest() {
+	plugin.SetTestCache(datastore.NewMemCache())
+}
+
+func (suite *dirTestSuite) TearDownTest() {
+	plugin.UnsetTestCache()
+}
+
+func (suite *dirTestSuite) newParent(children ...plugin.Entry) (*plugintest.MockParent, *dir) {
+	m := plugintest.NewMockParent()
+	m.On("List", mock.Anything).Return(children, nil)
+	d := newDir(nil, m)
+	d.root = &Root{}
+	return m, d
+}
+
+func (suite *dirTestSuite) TestLookup() {
+	child := plugintest.NewMockDelete("child")
+	m, d := suite.newParent(child)
+
+	var resp fuse.LookupResponse
+	node, err := d.Lookup(suite.ctx, &fuse.LookupRequest{Name: "child"}, &resp)
+	if suite.NoError(err) {
+		suite.IsType(&file{}, node)
+		suite.Equal(plugin.TTLOf(m, plugin.ListOp), resp.EntryValid)
+	}
+}
+
+func (suite *dirTestSuite) TestLookup_UncachedList() {
+	m, d := suite.newParent(plugintest.NewMockDelete("child"))
+	m.DisableDefaultCaching()
+
+	// The kernel's default is kept when the parent's List isn't cached.
+	resp := fuse.LookupResponse{EntryValid: time.Minute}
+	_, err := d.Lookup(suite.ctx, &fuse.LookupRequest{Name: "child"}, &resp)
+	if suite.NoError(err) {
+		suite.Equal(time.Minute, resp.EntryValid)
+	}
+}
+
+func (suite *dirTestSuite) TestLookup_Missing() {
+	m, d := suite.newParent(plugintest.NewMockDelete("child"))
+
+	// Missing children are remembered, so the parent's only listed once.
+	for i := 0; i < 2; i++ {
+		_, err := d.Lookup(suite.ctx, &fuse.LookupRequest{Name: "missing"}, &fuse.LookupResponse{})
+		suite.Equal(syscall.ENOENT, err)
+	}
+	m.AssertNumberOfCalls(suite.T(), "List", 1)
+
+	// Until the parent's cache is cleared.
+	plugin.ClearCacheFor(plugin.ID(m), false)
+	_, err := d.Lookup(suite.ctx, &fuse.LookupRequest{Name: "missing"}, &fuse.LookupResponse{})
+	suite.Equal(syscall.ENOENT, err)
+	m.AssertNumberOfCalls(suite.T(), "List", 2)
+}
+
+func (suite *dirTestSuite) TestIgnoredNames() {
+	m, d := suite.newParent(plugintest.NewMockDelete(".git"), plugintest.NewMockDelete("child"))
+	d.root.opts.IgnoredNames = []string{".git", "*.swp"}
+
+	for _, name := range []string{".git", ".child.swp"} {
+		_, err := d.Lookup(suite.ctx, &fuse.LookupRequest{Name: name}, &fuse.LookupResponse{})
+		suite.Equal(syscall.ENOENT, err)
+	}
+	m.AssertNotCalled(suite.T(), "List", mock.Anything)
+
+	dirents, err := d.ReadDirAll(suite.ctx)
+	if suite.NoError(err) {
+		suite.Equal([]fuse.Dirent{{Name: "child", Type: fuse.DT_File}}, dirents)
+	}
+
+	_, _, err = d.Create(suite.ctx, &fuse.CreateRequest{Name: ".child.swp"}, &fuse.CreateResponse{})
+	suite.Equal(syscall.EPERM, err)
+	_, err = d.Mkdir(suite.ctx, &fuse.MkdirRequest{Name: ".git"})
+	suite.Equal(syscall.EPERM, err)
+	suite.Equal(syscall.EPERM, d.Rename(suite.ctx, &fuse.RenameRequest{OldName: "child", NewName: ".git"}, d))
+}
+
+func (suite *dirTestSuite) newSlowParent(opts Opts) (*plugintest.MockParent, *dir, chan context.Context, chan struct{}) {
+	m := plugintest.NewMockParent()
+	listCtxs, release := make(chan context.Context, 1), make(chan struct{})
+	m.On("List", mock.Anything).Return([]plugin.Entry{}, nil).Run(func(args mock.Arguments) {
+		// Like a plugin that ignores cancellation.
+		listCtxs <- args.Get(0).(context.Context)
+		<-release
+	})
+	d := newDir(nil, m)
+	d.root = &Root{opts: opts}
+	return m, d, listCtxs, release
+}
+
+func (suite *dirTestSuite) TestReadDirAll_Timeout() {
+	_, d, listCtxs, release := suite.newSlowParent(Opts{Timeouts: map[string]time.Duration{"list": 10 * time.Millisecond}})
+	defer close(release)
+
+	_, err := d.ReadDirAll(suite.ctx)
+	suite.Equal(syscall.ETIMEDOUT, err)
+
+	// The plugin call's cancelled.
+	select {
+	case <-(<-listCtxs).Done():
+	case <-time.After(time.Second):
+		suite.Fail("List's context wasn't cancelled")
+	}
+}
+
+// cancellableParent is a parent whose List stops when it's cancelled.
+type cancellableParent struct {
+	*plugintest.MockParent
+	returned chan struct{}
+}
+
+func (p cancellableParent) List(ctx context.Context) ([]plugin.Entry, error) {
+	defer func() { p.returned <- struct{}{} }()
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (suite *dirTestSuite) TestLookup_TimeoutHonoredByPlugin() {
+	// List returns DeadlineExceeded as the timeout expires, which can be before the timer fires.
+	p := cancellableParent{plugintest.NewMockParent(), make(chan struct{}, 1)}
+	d := newDir(nil, p)
+	d.root = &Root{opts: Opts{DefaultTimeout: 10 * time.Millisecond}}
+
+	_, err := d.Lookup(suite.ctx, &fuse.LookupRequest{Name: "child"}, &fuse.LookupResponse{})
+	suite.Equal(syscall.ETIMEDOUT, err)
+	<-p.returned
+
+	plugin.ClearCacheFor(plugin.ID(p), false)
+	_, err = d.ReadDirAll(suite.ctx)
+	suite.Equal(syscall.ETIMEDOUT, err)
+	<-p.returned
+}
+
+func (suite *dirTestSuite) TestReadDirAll_KeepTimedOutCalls() {
+	m, d, listCtxs, release := suite.newSlowParent(Opts{DefaultTimeout: 10 * time.Millisecond, KeepTimedOutCalls: true})
+
+	_, err := d.ReadDirAll(suite.ctx)
+	suite.Equal(syscall.ETIMEDOUT, err)
+	suite.NoError((<-listCtxs).Err())
+
+	// The result of the plugin call that kept running is used by the next attempt.
+	close(release)
+	_, err = d.ReadDirAll(suite.ctx)
+	suite.NoError(err)
+	m.AssertNumberOfCalls(suite.T(), "List", 1)
+}
+
+func (suite *dirTestSuite) TestRemove() {
+	child := plugintest.NewMockDelete("child")
+	child.On("Delete", mock.Anything).Return(true, nil).Once()
+	_, d := suite.newParent(child)
+
+	err := d.Remove(suite.ctx, &fuse.RemoveRequest{Name: "child"})
+	suite.NoError(err)
+	child.AssertExpectations(suite.T())
+
+	// The deleted child's removed from the parent's cached list.
+	entries, err := d.children(suite.ctx)
+	suite.NoError(err)
+	_, ok := entries.Load("child")
+	suite.False(ok)
+}
+
+func (suite *dirTestSuite) TestRemove_MarkedForDeletion() {
+	child := plugintest.NewMockDelete("child")
+	child.On("Delete", mock.Anything).Return(false, nil).Once()
+	m, d := suite.newParent(child)
+
+	err := d.Remove(suite.ctx, &fuse.RemoveRequest{Name: "child"})
+	suite.NoError(err)
+	child.AssertExpectations(suite.T())
+
+	// The parent's relisted because the child may have changed.
+	_, err = d.children(suite.ctx)
+	suite.NoError(err)
+	m.AssertNumberOfCalls(suite.T(), "List", 2)
+}
+
+func (suite *dirTestSuite) TestRemove_Errors() {
+	for err, errno := range map[error]syscall.Errno{
+		plugin.InvalidInputErr{}:                 syscall.EINVAL,
+		os.ErrNotExist:                           syscall.ENOENT,
+		os.ErrExist:                              syscall.EEXIST,
+		os.ErrPermission:                         syscall.EACCES,
+		context.DeadlineExceeded:                 syscall.ETIMEDOUT,
+		syscall.EBUSY:                            syscall.EBUSY,
+		fmt.Errorf("wrapped: %w", syscall.EBUSY): syscall.EBUSY,
+	} {
+		suite.TearDownTest()
+		suite.SetupTest()
+		child := plugintest.NewMockDelete("child")
+		child.On("Delete", mock.Anything).Return(false, err).Once()
+		_, d := suite.newParent(child)
+
+		suite.Equal(errno, d.Remove(suite.ctx, &fuse.RemoveRequest{Name: "child"}), "for %v", err)
+	}
+
+	// Errors without an equivalent errno are returned as-is, which FUSE reports as EIO.
+	child := plugintest.NewMockDelete("child")
+	child.On("Delete", mock.Anything).Return(false, fmt.Errorf("failed")).Once()
+	suite.TearDownTest()
+	suite.SetupTest()
+	_, d := suite.newParent(child)
+	suite.EqualError(d.Remove(suite.ctx, &fuse.RemoveRequest{Name: "child"}), "failed")
+}
+
+func (suite *dirTestSuite) TestRemove_Unsupported() {
+	child := plugintest.NewMockRead()
+	_, d := suite.newParent(child)
+
+	suite.Equal(syscall.EPERM, d.Remove(suite.ctx, &fuse.RemoveRequest{Name: "mockr"}))
+	suite.Equal(syscall.ENOENT, d.Remove(suite.ctx, &fuse.RemoveRequest{Name: "missing"}))
+}
+
+func (suite *dirTestSuite) TestRemove_Dir() {
+	child := plugintest.NewMockDelete("child")
+	_, d := suite.newParent(child)
+
+	suite.Equal(syscall.ENOTDIR, d.Remove(suite.ctx, &fuse.RemoveRequest{Name: "child", Dir: true}))
+	child.AssertNotCalled(suite.T(), "Delete", mock.Anything)
+}
+
+func (suite *dirTestSuite) TestRemove_Disabled() {
+	child := plugintest.NewMockDelete("child")
+	_, d := suite.newParent(child)
+	d.root.opts.DisableDelete = true
+
+	suite.Equal(syscall.EPERM, d.Remove(suite.ctx, &fuse.RemoveRequest{Name: "child"}))
+	child.AssertNotCalled(suite.T(), "Delete", mock.Anything)
+}
+
+func (suite *dirTestSuite) TestReadOnly() {
+	child := plugintest.NewMockRename("child")
+	_, d := suite.newParent(child)
+	d.root.opts.ReadOnly = true
+
+	suite.Equal(syscall.EROFS, d.Remove(suite.ctx, &fuse.RemoveRequest{Name: "child"}))
+	suite.Equal(syscall.EROFS, d.Rename(suite.ctx, &fuse.RenameRequest{OldName: "child", NewName: "moved"}, d))
+	_, err := d.Mkdir(suite.ctx, &fuse.MkdirRequest{Name: "new"})
+	suite.Equal(syscall.EROFS, err)
+	_, _, err = d.Create(suite.ctx, &fuse.CreateRequest{Name: "new"}, &fuse.CreateResponse{})
+	suite.Equal(syscall.EROFS, err)
+	child.AssertNotCalled(suite.T(), "Rename", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *dirTestSuite) TestAttr_Owner() {
+	_, d := suite.newParent()
+	owner, group := uint32(1234), uint32(5678)
+	d.root.opts.UID, d.root.opts.GID, d.root.opts.Umask = &owner, &group, 0027
+
+	var attr fuse.Attr
+	suite.NoError(d.Attr(suite.ctx, &attr))
+	suite.Equal(owner, attr.Uid)
+	suite.Equal(group, attr.Gid)
+	suite.Equal(os.ModeDir|0550, attr.Mode)
+}
+
+func (suite *dirTestSuite) TestRename() {
+	child := plugintest.NewMockRename("child")
+	_, d := suite.newParent(child)
+	newParent, newDir := suite.newParent()
+
+	moved := plugintest.NewMockBase()
+	child.On("Rename", mock.Anything, newParent, "moved").Return(moved, nil).Once()
+
+	err := d.Rename(suite.ctx, &fuse.RenameRequest{OldName: "child", NewName: "moved"}, newDir)
+	suite.NoError(err)
+	child.AssertExpectations(suite.T())
+}
+
+func (suite *dirTestSuite) TestRename_Unsupported() {
+	child := plugintest.NewMockRename("child")
+	child.On("Rename", mock.Anything, mock.Anything, "moved").Return(plugintest.NewMockBase(), plugin.ErrCrossParentRename).Once()
+	_, d := suite.newParent(child, plugintest.NewMockRead())
+
+	// mv falls back to copying and deleting an entry when renaming it fails with EXDEV.
+	suite.Equal(syscall.EXDEV, d.Rename(suite.ctx, &fuse.RenameRequest{OldName: "child", NewName: "moved"}, d))
+	suite.Equal(syscall.EXDEV, d.Rename(suite.ctx, &fuse.RenameRequest{OldName: "mockr", NewName: "moved"}, d))
+	suite.Equal(syscall.ENOENT, d.Rename(suite.ctx, &fuse.RenameRequest{OldName: "missing", NewName: "moved"}, d))
+}
+
+func TestDir(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	suite.Run(t, &dirTestSuite{ctx: ctx})
+	cancel()
+}
@@ -9,7 +9,6 @@ import (
 
 	"bazil.org/fuse"
 	"bazil.org/fuse/fs"
-	"bazil.org/fuse/fuseutil"
 	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/plugin"
 )
@@ -42,20 +41,33 @@ import (
 // length of data available to read.
 //
 // `writers` are used to track in-progress writes so we know when to `plugin.Write` on `Flush`
+//
+// `data` is kept in memory until it grows past the `MaxWriteBufferSize` option, then it's moved
+// to a temporary file. It's sent to *StreamWritable* entries as it's read from the buffer.
+//
+// *SequentialReadable* entries are read from a stream for each handle, rather than with
+// `plugin.Read`, so that their content doesn't need to be held in memory. They're opened in direct
+// IO mode so that the kernel doesn't read ahead of where the stream is.
 type file struct {
 	fuseNode
 
 	mux sync.Mutex
 	// Handles with in-progress writes
 	writers map[fuse.HandleID]struct{}
-	// Only valid if len(writers) > 0
-	data []byte
+	// Only valid if len(writers) > 0. Use buffer to get it.
+	data *writeBuffer
 	// Size of readable content, necessary for *non-file-like* entries
 	readSize uint64
+	// Streams of a SequentialReadable entry's content, by handle
+	streams map[fuse.HandleID]*contentStream
 }
 
 func newFile(p *dir, e plugin.Entry) *file {
-	return &file{fuseNode: newFuseNode("f", p, e), writers: make(map[fuse.HandleID]struct{})}
+	return &file{
+		fuseNode: newFuseNode("f", p, e),
+		writers:  make(map[fuse.HandleID]struct{}),
+		streams:  make(map[fuse.HandleID]*contentStream),
+	}
 }
 
 func (f *file) isFileLikeEntry() bool {
@@ -76,14 +88,9 @@ func (f *file) Attr(ctx context.Context, a *fuse.Attr) error {
 	f.mux.Lock()
 	defer f.mux.Unlock()
 
-	if !f.useLocalContent() {
-		// Fetch updated attributes only if we're not currently writing to it.
-		entry, err := f.refind(ctx)
-		if err != nil {
-			activity.Warnf(ctx, "FUSE: Attr errored %v, %v", f, err)
-			return err
-		}
-		f.entry = entry
+	if err := f.refreshEntry(ctx); err != nil {
+		activity.Warnf(ctx, "FUSE: Attr errored %v, %v", f, err)
+		return err
 	}
 
 	f.fillAttr(a)
@@ -91,9 +98,51 @@ func (f *file) Attr(ctx context.Context, a *fuse.Attr) error {
 	return nil
 }
 
+// refreshEntry updates the entry so that its attributes and metadata are current, unless we're
+// writing to it. The caller must hold mux.
+func (f *file) refreshEntry(ctx context.Context) error {
+	if f.useLocalContent() {
+		return nil
+	}
+	entry, err := f.refindWithTimeout(ctx, "attr")
+	if err != nil {
+		return err
+	}
+	f.entry = entry
+	return nil
+}
+
+var _ = fs.NodeGetxattrer(&file{})
+var _ = fs.NodeListxattrer(&file{})
+
+func (f *file) Getxattr(ctx context.Context, req *fuse.GetxattrRequest, resp *fuse.GetxattrResponse) error {
+	if !isWashXattr(req.Name) {
+		return fuse.ErrNoXattr
+	}
+
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	if err := f.refreshEntry(ctx); err != nil {
+		activity.Warnf(ctx, "FUSE: Getxattr errored %v, %v", f, err)
+		return toErrno(err)
+	}
+	return getxattr(f, f.entry, req, resp)
+}
+
+func (f *file) Listxattr(ctx context.Context, req *fuse.ListxattrRequest, resp *fuse.ListxattrResponse) error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	if err := f.refreshEntry(ctx); err != nil {
+		activity.Warnf(ctx, "FUSE: Listxattr errored %v, %v", f, err)
+		return toErrno(err)
+	}
+	listxattr(f, f.entry, resp)
+	return nil
+}
+
 func (f *file) fillAttr(a *fuse.Attr) {
 	attr := plugin.Attributes(f.entry)
-	applyAttr(a, attr, defaultMode(f.entry))
+	f.applyAttr(a, attr, defaultMode(f.entry))
 
 	if f.useLocalContent() || !f.isFileLikeEntry() {
 		// Use whatever size we know locally. Retrieving content can be expensive so we settle for
@@ -127,10 +176,15 @@ func (f *file) Open(ctx context.Context, req *fuse.OpenRequest, resp *fuse.OpenR
 	f.mux.Lock()
 	defer f.mux.Unlock()
 	activity.Record(ctx, "FUSE: Open %v: %+v", f, *req)
+	if !req.Flags.IsReadOnly() {
+		if err := f.checkWritable(ctx, "Open for writing"); err != nil {
+			return nil, err
+		}
+	}
 
 	if !f.useLocalContent() {
 		// Check for an updated entry in case it has static content, like for preloaded external plugin entries.
-		entry, err := f.refind(ctx)
+		entry, err := f.refindWithTimeout(ctx, "open")
 		if err != nil {
 			activity.Warnf(ctx, "FUSE: Open errored %v, %v", f, err)
 			return nil, err
@@ -158,7 +212,9 @@ func (f *file) Open(ctx context.Context, req *fuse.OpenRequest, resp *fuse.OpenR
 		return nil, syscall.ENOTSUP
 	}
 
-	if !f.isFileLikeEntry() {
+	if _, ok := f.entry.(plugin.SequentialReadable); ok {
+		resp.Flags |= fuse.OpenDirectIO
+	} else if !f.isFileLikeEntry() {
 		// Open the file in direct IO mode to avoid the kernel page cache. This also enables FUSE to
 		// still read the entry's content so that built-in tools like cat and grep still work.
 		resp.Flags |= fuse.OpenDirectIO
@@ -166,17 +222,28 @@ func (f *file) Open(ctx context.Context, req *fuse.OpenRequest, resp *fuse.OpenR
 
 	if f.isFileLikeEntry() || req.Flags.IsReadOnly() {
 		// Get the entry's readable size if we expect to do any reads or keep a local representation.
-		size, err := plugin.Size(ctx, f.entry)
+		entry := f.entry
+		size, err := f.withTimeout(ctx, "open", func(ctx context.Context) (interface{}, error) {
+			return plugin.Size(ctx, entry)
+		})
 		if err != nil {
 			activity.Warnf(ctx, "FUSE: Size errored %v, %v", f, err)
 			return nil, err
 		}
-		f.readSize = size
+		f.readSize = size.(uint64)
 	}
 
 	return f, nil
 }
 
+// buffer returns the buffer of local content for writes, creating it if needed.
+func (f *file) buffer() *writeBuffer {
+	if f.data == nil {
+		f.data = newWriteBuffer(f.options())
+	}
+	return f.data
+}
+
 func (f *file) releaseWriter(ctx context.Context, handle fuse.HandleID) {
 	if _, ok := f.writers[handle]; ok {
 		delete(f.writers, handle)
@@ -185,7 +252,12 @@ func (f *file) releaseWriter(ctx context.Context, handle fuse.HandleID) {
 			// If we just released the last writer, release the data buffer to conserve memory and
 			// invalidate cache on the entry and its parent so we get updated content and size on the
 			// next request. Leave size for entries that don't set it.
-			f.data = nil
+			if f.data != nil {
+				if err := f.data.Close(); err != nil {
+					activity.Warnf(ctx, "FUSE: Closing the write buffer of %v errored: %v", f, err)
+				}
+				f.data = nil
+			}
 			deleted := plugin.ClearCacheFor(plugin.ID(f.entry), true)
 			activity.Record(ctx, "Clear cache for %v: %+v", f.entry, deleted)
 		}
@@ -208,6 +280,14 @@ func (f *file) Release(ctx context.Context, req *fuse.ReleaseRequest) error {
 		}
 	}
 
+	f.mux.Lock()
+	stream, ok := f.streams[req.Handle]
+	delete(f.streams, req.Handle)
+	f.mux.Unlock()
+	if ok {
+		stream.close(ctx)
+	}
+
 	// Release writer and cleanup if all writers are released. Note that this is usually a noop for
 	// non-file-like entries, they will have released the writers immediately after `plugin.Write`.
 	f.releaseWriter(ctx, req.Handle)
@@ -220,58 +300,118 @@ var _ = fs.HandleReader(&file{})
 
 func (f *file) Read(ctx context.Context, req *fuse.ReadRequest, resp *fuse.ReadResponse) error {
 	f.mux.Lock()
+	if stream := f.contentStream(req.Handle); stream != nil {
+		// Reading a stream can block until the plugin has more content, so it only holds the
+		// stream's lock.
+		f.mux.Unlock()
+		return f.readStream(ctx, stream, req, resp)
+	}
 	defer f.mux.Unlock()
 
 	if f.useLocalContent() {
-		fuseutil.HandleRead(req, resp, f.data)
-	} else {
-		data, err := plugin.ReadWithAnalytics(ctx, f.entry, int64(req.Size), req.Offset)
+		data := make([]byte, req.Size)
+		n, err := f.buffer().ReadAt(data, req.Offset)
 		if err != nil && err != io.EOF {
 			activity.Warnf(ctx, "FUSE: Read errored %v, %v", f, err)
+			return err
+		}
+		resp.Data = data[:n]
+	} else {
+		entry := f.entry
+		data, err := f.withTimeout(ctx, "read", func(ctx context.Context) (interface{}, error) {
+			data, err := plugin.ReadWithAnalytics(ctx, entry, int64(req.Size), req.Offset)
+			if err != nil && err != io.EOF {
+				return nil, err
+			}
 			// If we don't ignore EOF, then cat will display an input/output error message
 			// for entries with unknown content size.
+			return data, nil
+		})
+		if err != nil {
+			activity.Warnf(ctx, "FUSE: Read errored %v, %v", f, err)
 			return err
 		}
-		resp.Data = data
+		resp.Data = data.([]byte)
 	}
 
 	activity.Record(ctx, "FUSE: Read %v/%v bytes starting at %v from %v", len(resp.Data), req.Size, req.Offset, f)
 	return nil
 }
 
+// contentStream returns the handle's stream of a SequentialReadable entry's content, or nil if
+// reads aren't served from a stream. The caller must hold mux.
+func (f *file) contentStream(handle fuse.HandleID) *contentStream {
+	if f.useLocalContent() {
+		return nil
+	}
+	entry, ok := f.entry.(plugin.SequentialReadable)
+	if !ok {
+		return nil
+	}
+	stream, ok := f.streams[handle]
+	if !ok {
+		stream = newContentStream(entry)
+		f.streams[handle] = stream
+	}
+	return stream
+}
+
+// readStream reads from a handle's stream. Streams aren't bounded by the read timeout because
+// programs read them gradually, and each one's shared by all of the handle's reads. It's called
+// without holding mux, so it identifies the file by the stream's entry.
+func (f *file) readStream(ctx context.Context, stream *contentStream, req *fuse.ReadRequest, resp *fuse.ReadResponse) error {
+	id := plugin.ID(stream.entry)
+	data, err := stream.read(ctx, int64(req.Size), req.Offset)
+	if err != nil && err != io.EOF {
+		activity.Warnf(ctx, "FUSE: Read errored %v, %v", id, err)
+		return err
+	}
+	resp.Data = data
+	activity.Record(ctx, "FUSE: Read %v/%v bytes starting at %v from %v", len(resp.Data), req.Size, req.Offset, id)
+	return nil
+}
+
 var _ = fs.HandleWriter(&file{})
 
 func (f *file) Write(ctx context.Context, req *fuse.WriteRequest, resp *fuse.WriteResponse) error {
 	f.mux.Lock()
 	defer f.mux.Unlock()
+	if err := f.checkWritable(ctx, "Write"); err != nil {
+		return err
+	}
 
 	// Ensure handle is in list of writers.
 	f.writers[req.Handle] = struct{}{}
 
+	buf := f.buffer()
 	if f.isFileLikeEntry() {
 		// If starting write beyond the current length, read to fill it in.
-		if start := int64(len(f.data)); req.Offset > start {
+		if start := buf.Len(); req.Offset > start {
 			data, err := f.load(ctx, start, req.Offset)
 			if err != nil {
 				activity.Warnf(ctx, "FUSE: Write errored %v, %v", f, err)
 				return err
 			}
-			f.data = append(f.data, data...)
+			if _, err := buf.WriteAt(data, start); err != nil {
+				activity.Warnf(ctx, "FUSE: Write errored %v, %v", f, err)
+				return err
+			}
 		}
 	}
 
-	// Expand the buffer if necessary to store the write data.
-	newLen := req.Offset + int64(len(req.Data))
-	if newLen := int(newLen); newLen > len(f.data) {
-		f.data = append(f.data, make([]byte, newLen-len(f.data))...)
+	// The buffer expands if necessary to store the write data.
+	n, err := buf.WriteAt(req.Data, req.Offset)
+	if err != nil {
+		activity.Warnf(ctx, "FUSE: Write errored %v, %v", f, err)
+		return err
 	}
 
 	// If file-like, then update readable size to reflect the expanded buffer.
-	if f.isFileLikeEntry() && f.readSize < uint64(newLen) {
-		f.readSize = uint64(newLen)
+	if newLen := uint64(buf.Len()); f.isFileLikeEntry() && f.readSize < newLen {
+		f.readSize = newLen
 	}
 
-	resp.Size = copy(f.data[req.Offset:], req.Data)
+	resp.Size = n
 	activity.Record(ctx, "FUSE: Write %v/%v bytes starting at %v from %v", resp.Size, len(req.Data), req.Offset, f)
 	return nil
 }
@@ -303,7 +443,8 @@ func (f *file) Flush(ctx context.Context, req *fuse.FlushRequest) error {
 	}
 
 	// If this handle had an open writer, write current data.
-	dataLen := int64(len(f.data))
+	buf := f.buffer()
+	dataLen := buf.Len()
 	if f.isFileLikeEntry() {
 		// Only file-like entries keep data and readSize in sync.
 		if uint64(dataLen) > f.readSize {
@@ -317,18 +458,25 @@ func (f *file) Flush(ctx context.Context, req *fuse.FlushRequest) error {
 				activity.Warnf(ctx, "FUSE: Error loading %v, %v", f, err)
 				return err
 			}
-			f.data = append(f.data, data...)
+			if _, err := buf.WriteAt(data, dataLen); err != nil {
+				activity.Warnf(ctx, "FUSE: Error loading %v, %v", f, err)
+				return err
+			}
 
 			// If a call to `Setattr` was used to increase the file's size, then `load` will have
 			// returned EOF and the loaded data would not be enough to increase the local content buffer
 			// to `readSize`. Fill the rest with null characters.
-			if sz := uint64(len(f.data)); sz < f.readSize {
-				f.data = append(f.data, make([]byte, f.readSize-sz)...)
+			if sz := uint64(buf.Len()); sz < f.readSize {
+				if err := buf.Truncate(int64(f.readSize)); err != nil {
+					activity.Warnf(ctx, "FUSE: Error loading %v, %v", f, err)
+					return err
+				}
 			}
 		}
 	}
 
-	if err := plugin.WriteWithAnalytics(ctx, f.entry.(plugin.Writable), f.data); err != nil {
+	writable := f.entry.(plugin.Writable)
+	if err := plugin.WriteFromWithAnalytics(ctx, writable, buf.Reader(), buf.Len()); err != nil {
 		activity.Warnf(ctx, "FUSE: Error writing %v, %v", f, err)
 		return err
 	}
@@ -347,6 +495,9 @@ func (f *file) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *fuse
 	f.mux.Lock()
 	defer f.mux.Unlock()
 	activity.Record(ctx, "FUSE: Setattr[%v] %v: %+v", req.Handle, f, *req)
+	if err := f.checkWritable(ctx, "Setattr"); err != nil {
+		return err
+	}
 
 	if req.Valid.Size() {
 		if !req.Valid.Handle() {
@@ -365,10 +516,9 @@ func (f *file) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *fuse
 		} else {
 			// Non-file-like entries use `data` as a write buffer. There's nothing to fill in from, so
 			// just resize as necessary.
-			if curLen := uint64(len(f.data)); req.Size > curLen {
-				f.data = append(f.data, make([]byte, req.Size-uint64(len(f.data)))...)
-			} else if req.Size < curLen {
-				f.data = f.data[:req.Size]
+			if err := f.buffer().Truncate(int64(req.Size)); err != nil {
+				activity.Warnf(ctx, "FUSE: Setattr errored %v, %v", f, err)
+				return err
 			}
 		}
 	}
@@ -2,7 +2,12 @@ package fuse
 
 import (
 	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	"bazil.org/fuse"
 	"bazil.org/fuse/fs"
@@ -258,6 +263,130 @@ func (suite *fileTestSuite) TestAttrWithWriters() {
 	suite.Equal(uint64(5), attr.Size)
 }
 
+func (suite *fileTestSuite) TestReadOnly() {
+	m := plugintest.NewMockReadWrite()
+	m.Attributes().SetSize(0)
+	f := newFile(nil, m)
+	f.root = &Root{opts: Opts{ReadOnly: true}}
+
+	var resp fuse.OpenResponse
+	_, err := f.Open(suite.ctx, &fuse.OpenRequest{Flags: fuse.OpenWriteOnly}, &resp)
+	suite.Equal(syscall.EROFS, err)
+	_, err = f.Open(suite.ctx, &fuse.OpenRequest{Flags: fuse.OpenReadOnly}, &resp)
+	suite.NoError(err)
+
+	suite.Equal(syscall.EROFS, f.Write(suite.ctx, &fuse.WriteRequest{Data: []byte("hello")}, &fuse.WriteResponse{}))
+	suite.Equal(syscall.EROFS, f.Setattr(suite.ctx, &fuse.SetattrRequest{Valid: fuse.SetattrSize}, &fuse.SetattrResponse{}))
+	suite.Empty(f.writers)
+}
+
+func (suite *fileTestSuite) TestRead_SequentialReadableEntry() {
+	m := plugintest.NewMockSequentialRead()
+	newStream := func() io.ReadCloser {
+		return ioutil.NopCloser(strings.NewReader("hello world"))
+	}
+	m.On("ReadStream", mock.Anything).Return(newStream(), nil).Once()
+
+	f := newFile(nil, m)
+	var openResp fuse.OpenResponse
+	handle, err := f.Open(suite.ctx, &fuse.OpenRequest{Flags: fuse.OpenReadOnly}, &openResp)
+	if !suite.NoError(err) {
+		return
+	}
+	suite.Equal(fuse.OpenDirectIO, openResp.Flags&fuse.OpenDirectIO)
+
+	// Reads that continue where the last one ended use the same stream.
+	read := func(offset int64, size int) string {
+		var resp fuse.ReadResponse
+		err := handle.(fs.HandleReader).Read(suite.ctx, &fuse.ReadRequest{Offset: offset, Size: size}, &resp)
+		suite.NoError(err)
+		return string(resp.Data)
+	}
+	suite.Equal("hello", read(0, 5))
+	suite.Equal(" wor", read(5, 4))
+	suite.Equal("ld", read(9, 10))
+	m.AssertExpectations(suite.T())
+
+	// Reads before where the last one ended start a new stream.
+	m.On("ReadStream", mock.Anything).Return(newStream(), nil).Once()
+	suite.Equal("world", read(6, 5))
+	m.AssertExpectations(suite.T())
+
+	suite.NoError(f.Release(suite.ctx, &fuse.ReleaseRequest{}))
+	suite.Empty(f.streams)
+}
+
+func (suite *fileTestSuite) TestRead_SequentialReadableEntryBlocked() {
+	m := plugintest.NewMockSequentialRead()
+	r, w := io.Pipe()
+	m.On("ReadStream", mock.Anything).Return(r, nil).Once()
+
+	f := newFile(nil, m)
+	handle, err := f.Open(suite.ctx, &fuse.OpenRequest{Flags: fuse.OpenReadOnly}, &fuse.OpenResponse{})
+	if !suite.NoError(err) {
+		return
+	}
+
+	readDone := make(chan string)
+	go func() {
+		var resp fuse.ReadResponse
+		err := handle.(fs.HandleReader).Read(suite.ctx, &fuse.ReadRequest{Size: 5}, &resp)
+		suite.NoError(err)
+		readDone <- string(resp.Data)
+	}()
+
+	// Other requests on the file aren't blocked while the stream waits for content.
+	attrDone := make(chan error)
+	go func() {
+		attrDone <- f.Attr(suite.ctx, &fuse.Attr{})
+	}()
+	select {
+	case err := <-attrDone:
+		suite.NoError(err)
+	case <-time.After(time.Second):
+		suite.Fail("Attr was blocked by a stream read")
+	}
+
+	_, err = w.Write([]byte("hello"))
+	suite.NoError(err)
+	suite.Equal("hello", <-readDone)
+	suite.NoError(f.Release(suite.ctx, &fuse.ReleaseRequest{}))
+}
+
+func (suite *fileTestSuite) TestGetxattr() {
+	m := plugintest.NewMockRead()
+	m.SetPartialMetadata(map[string]interface{}{"state": "running", "labels": map[string]string{"app": "web"}})
+	f := newFile(nil, m)
+
+	for name, expected := range map[string]string{
+		"user.wash.type_id":     plugin.TypeID(m),
+		"user.wash.actions":     "read",
+		"user.wash.path":        "/mockr",
+		"user.wash.meta.state":  `"running"`,
+		"user.wash.meta.labels": `{"app":"web"}`,
+	} {
+		var resp fuse.GetxattrResponse
+		if suite.NoError(f.Getxattr(suite.ctx, &fuse.GetxattrRequest{Name: name}, &resp)) {
+			suite.Equal(expected, string(resp.Xattr), name)
+		}
+	}
+
+	for _, name := range []string{"user.wash.meta.missing", "security.selinux"} {
+		var resp fuse.GetxattrResponse
+		suite.Equal(fuse.ErrNoXattr, f.Getxattr(suite.ctx, &fuse.GetxattrRequest{Name: name}, &resp))
+	}
+}
+
+func (suite *fileTestSuite) TestListxattr() {
+	m := plugintest.NewMockBase()
+	m.SetPartialMetadata(map[string]interface{}{"state": "running"})
+	f := newFile(nil, m)
+
+	var resp fuse.ListxattrResponse
+	suite.NoError(f.Listxattr(suite.ctx, &fuse.ListxattrRequest{}, &resp))
+	suite.Equal("user.wash.actions\x00user.wash.meta.state\x00user.wash.path\x00user.wash.type_id\x00", string(resp.Xattr))
+}
+
 func (suite *fileTestSuite) TestSetAttr_NoHandle() {
 	m := plugintest.NewMockReadWrite()
 	m.Attributes().SetSize(0)
@@ -0,0 +1,61 @@
+package fuse
+
+import (
+	"context"
+	"os"
+	"syscall"
+
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	log "github.com/sirupsen/logrus"
+)
+
+// ==== FUSE Symlink Interface ====
+
+// link presents a plugin.Linkable entry as a symlink. Its target is relative to the link's
+// parent so that it resolves within the mount.
+type link struct {
+	fuseNode
+}
+
+var _ fs.Node = (*link)(nil)
+var _ = fs.NodeReadlinker(&link{})
+
+func newLink(p *dir, e plugin.Linkable) *link {
+	return &link{newFuseNode("l", p, e)}
+}
+
+func (l *link) Attr(ctx context.Context, a *fuse.Attr) error {
+	// Like dir, refind the entry in case its attributes changed.
+	entry, err := l.refindWithTimeout(ctx, "attr")
+	if err != nil {
+		activity.Warnf(ctx, "FUSE: Attr errored %v, %v", l, err)
+		return err
+	}
+
+	l.applyAttr(a, plugin.Attributes(entry), os.ModeSymlink|0777)
+	// The kernel only calls Readlink on symlinks, so keep the mode's type even if the plugin
+	// set a different one.
+	a.Mode |= os.ModeSymlink
+	log.Debugf("FUSE: Attr %v: %+v", l, *a)
+	return nil
+}
+
+func (l *link) Readlink(ctx context.Context, req *fuse.ReadlinkRequest) (string, error) {
+	entry, err := l.refindWithTimeout(ctx, "attr")
+	if err != nil {
+		activity.Warnf(ctx, "FUSE: Readlink errored %v, %v", l, err)
+		return "", toErrno(err)
+	}
+	linkable, ok := entry.(plugin.Linkable)
+	if !ok {
+		// The entry was replaced by something that isn't a link.
+		return "", syscall.EINVAL
+	}
+
+	target := plugin.RelativeLinkTarget(linkable)
+	activity.Record(ctx, "FUSE: Readlink %v: %v", l, target)
+	return target, nil
+}
@@ -0,0 +1,118 @@
+package fuse
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+// writeBuffer holds a file's content while it's being written. It's kept in memory until it
+// grows larger than maxMemSize, then it's moved to a temporary file in dir so that copying a large
+// file into Wash doesn't need all of it in memory. The temporary file is removed as soon as it's
+// created, so its disk space is freed when the buffer's closed or Wash exits.
+type writeBuffer struct {
+	data       []byte
+	file       *os.File
+	size       int64
+	maxMemSize int64
+	dir        string
+}
+
+func newWriteBuffer(opts Opts) *writeBuffer {
+	return &writeBuffer{maxMemSize: int64(opts.MaxWriteBufferSize), dir: opts.WriteBufferDir}
+}
+
+// Len returns the size of the buffer's content.
+func (b *writeBuffer) Len() int64 {
+	return b.size
+}
+
+// WriteAt writes p at off, filling any gap before it with zeros.
+func (b *writeBuffer) WriteAt(p []byte, off int64) (int, error) {
+	end := off + int64(len(p))
+	if err := b.spillIfLarger(end); err != nil {
+		return 0, err
+	}
+
+	if b.file != nil {
+		n, err := b.file.WriteAt(p, off)
+		if written := off + int64(n); written > b.size {
+			b.size = written
+		}
+		return n, err
+	}
+
+	if end > int64(len(b.data)) {
+		b.data = append(b.data, make([]byte, end-int64(len(b.data)))...)
+	}
+	n := copy(b.data[off:], p)
+	b.size = int64(len(b.data))
+	return n, nil
+}
+
+// Truncate changes the size of the buffer's content, filling it with zeros if it grows.
+func (b *writeBuffer) Truncate(size int64) error {
+	if err := b.spillIfLarger(size); err != nil {
+		return err
+	}
+
+	if b.file != nil {
+		if err := b.file.Truncate(size); err != nil {
+			return err
+		}
+	} else if size > int64(len(b.data)) {
+		b.data = append(b.data, make([]byte, size-int64(len(b.data)))...)
+	} else {
+		b.data = b.data[:size]
+	}
+	b.size = size
+	return nil
+}
+
+// ReadAt reads the buffer's content starting at off. It returns io.EOF if it reaches the end of
+// the content.
+func (b *writeBuffer) ReadAt(p []byte, off int64) (int, error) {
+	if b.file != nil {
+		return io.NewSectionReader(b.file, 0, b.size).ReadAt(p, off)
+	}
+	return bytes.NewReader(b.data).ReadAt(p, off)
+}
+
+// Reader returns a reader of the buffer's content.
+func (b *writeBuffer) Reader() io.Reader {
+	return io.NewSectionReader(b, 0, b.size)
+}
+
+// Close frees the buffer's temporary file, if it has one.
+func (b *writeBuffer) Close() error {
+	b.data = nil
+	if b.file == nil {
+		return nil
+	}
+	err := b.file.Close()
+	b.file = nil
+	return err
+}
+
+func (b *writeBuffer) spillIfLarger(size int64) error {
+	if b.file != nil || b.maxMemSize <= 0 || size <= b.maxMemSize {
+		return nil
+	}
+
+	file, err := ioutil.TempFile(b.dir, "wash-write-")
+	if err != nil {
+		return fmt.Errorf("could not create a file to buffer the write: %w", err)
+	}
+	if err := os.Remove(file.Name()); err != nil {
+		file.Close()
+		return fmt.Errorf("could not unlink %v: %w", file.Name(), err)
+	}
+	if _, err := file.Write(b.data); err != nil {
+		file.Close()
+		return fmt.Errorf("could not buffer the write: %w", err)
+	}
+	b.file, b.data = file, nil
+	return nil
+}
@@ -0,0 +1,79 @@
+package fuse
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type writeBufferTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (suite *writeBufferTestSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "wash-write-buffer")
+	suite.Require().NoError(err)
+	suite.dir = dir
+}
+
+func (suite *writeBufferTestSuite) TearDownTest() {
+	suite.NoError(os.RemoveAll(suite.dir))
+}
+
+func (suite *writeBufferTestSuite) content(b *writeBuffer) string {
+	data, err := ioutil.ReadAll(b.Reader())
+	suite.NoError(err)
+	return string(data)
+}
+
+func (suite *writeBufferTestSuite) testBuffer(b *writeBuffer) {
+	_, err := b.WriteAt([]byte("hello"), 0)
+	suite.NoError(err)
+	_, err = b.WriteAt([]byte("world"), 6)
+	suite.NoError(err)
+	suite.Equal(int64(11), b.Len())
+	suite.Equal("hello\x00world", suite.content(b))
+
+	data := make([]byte, 10)
+	n, err := b.ReadAt(data, 6)
+	suite.Equal(io.EOF, err)
+	suite.Equal("world", string(data[:n]))
+
+	suite.NoError(b.Truncate(4))
+	suite.Equal("hell", suite.content(b))
+	suite.NoError(b.Truncate(6))
+	suite.Equal("hell\x00\x00", suite.content(b))
+}
+
+func (suite *writeBufferTestSuite) TestInMemory() {
+	b := newWriteBuffer(Opts{WriteBufferDir: suite.dir})
+	suite.testBuffer(b)
+	suite.Nil(b.file)
+	suite.NoError(b.Close())
+}
+
+func (suite *writeBufferTestSuite) TestSpilled() {
+	b := newWriteBuffer(Opts{MaxWriteBufferSize: 8, WriteBufferDir: suite.dir})
+	_, err := b.WriteAt([]byte("small"), 0)
+	suite.NoError(err)
+	suite.Nil(b.file)
+
+	suite.testBuffer(b)
+	suite.NotNil(b.file)
+	suite.Nil(b.data)
+	// The temporary file is removed as soon as it's created.
+	files, err := ioutil.ReadDir(suite.dir)
+	suite.NoError(err)
+	suite.Empty(files)
+
+	suite.NoError(b.Close())
+	suite.Nil(b.file)
+}
+
+func TestWriteBuffer(t *testing.T) {
+	suite.Run(t, new(writeBufferTestSuite))
+}
@@ -0,0 +1,71 @@
+package fuse
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"bazil.org/fuse"
+	"github.com/puppetlabs/wash/plugin"
+	log "github.com/sirupsen/logrus"
+)
+
+// Wash's extended attributes are in the user namespace so that they can be read without
+// privileges on Linux. They let tools that run inside the mount, like getfattr or xattr,
+// see an entry's metadata without calling `wash meta`.
+const (
+	xattrPrefix     = "user.wash."
+	xattrMetaPrefix = xattrPrefix + "meta."
+)
+
+// xattrs returns the entry's extended attributes. Top-level keys of its partial metadata
+// are JSON-encoded.
+func xattrs(entry plugin.Entry) map[string][]byte {
+	actions := plugin.SupportedActionsOf(entry)
+	sort.Strings(actions)
+	attrs := map[string][]byte{
+		xattrPrefix + "type_id": []byte(plugin.TypeID(entry)),
+		xattrPrefix + "actions": []byte(strings.Join(actions, ",")),
+		xattrPrefix + "path":    []byte(plugin.ID(entry)),
+	}
+	for key, value := range plugin.PartialMetadata(entry) {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			// Metadata comes from JSON, so this shouldn't happen.
+			continue
+		}
+		attrs[xattrMetaPrefix+key] = encoded
+	}
+	return attrs
+}
+
+// isWashXattr returns whether name could be one of Wash's extended attributes. Tools like ls
+// ask for others, such as security.selinux, for every file they see; those are rejected without
+// refinding the entry.
+func isWashXattr(name string) bool {
+	return strings.HasPrefix(name, xattrPrefix)
+}
+
+// getxattr and listxattr serve an entry's extended attributes. Like Attr, they happen a lot so
+// they're logged rather than recorded as activity.
+func getxattr(node fmt.Stringer, entry plugin.Entry, req *fuse.GetxattrRequest, resp *fuse.GetxattrResponse) error {
+	value, ok := xattrs(entry)[req.Name]
+	if !ok {
+		return fuse.ErrNoXattr
+	}
+	log.Debugf("FUSE: Getxattr %v %v", node, req.Name)
+	resp.Xattr = value
+	return nil
+}
+
+func listxattr(node fmt.Stringer, entry plugin.Entry, resp *fuse.ListxattrResponse) {
+	attrs := xattrs(entry)
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	log.Debugf("FUSE: Listxattr %v: %v", node, names)
+	resp.Append(names...)
+}
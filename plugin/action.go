@@ -8,6 +8,7 @@ const (
 	UnsupportedSignature MethodSignature = iota
 	DefaultSignature
 	BlockReadableSignature
+	SequentialReadableSignature
 )
 
 // Action represents a Wash action.
@@ -52,6 +53,9 @@ var listAction = newAction("list", "Parent", func(e Entry) MethodSignature {
 })
 
 var readAction = newAction("read", "Readable", func(e Entry) MethodSignature {
+	if _, ok := e.(SequentialReadable); ok {
+		return SequentialReadableSignature
+	}
 	if _, ok := e.(Readable); ok {
 		return DefaultSignature
 	}
@@ -82,6 +86,27 @@ var execAction = newAction("exec", "Execable", func(e Entry) MethodSignature {
 	return UnsupportedSignature
 })
 
+var createAction = newAction("create", "Creatable", func(e Entry) MethodSignature {
+	if _, ok := e.(Creatable); ok {
+		return DefaultSignature
+	}
+	return UnsupportedSignature
+})
+
+var mkdirAction = newAction("mkdir", "DirCreatable", func(e Entry) MethodSignature {
+	if _, ok := e.(DirCreatable); ok {
+		return DefaultSignature
+	}
+	return UnsupportedSignature
+})
+
+var renameAction = newAction("rename", "Renamable", func(e Entry) MethodSignature {
+	if _, ok := e.(Renamable); ok {
+		return DefaultSignature
+	}
+	return UnsupportedSignature
+})
+
 var deleteAction = newAction("delete", "Deletable", func(e Entry) MethodSignature {
 	if _, ok := e.(Deletable); ok {
 		return DefaultSignature
@@ -96,6 +121,13 @@ var signalAction = newAction("signal", "Signalable", func(e Entry) MethodSignatu
 	return UnsupportedSignature
 })
 
+var forwardAction = newAction("forward", "Forwardable", func(e Entry) MethodSignature {
+	if _, ok := e.(Forwardable); ok {
+		return DefaultSignature
+	}
+	return UnsupportedSignature
+})
+
 // ListAction represents the list action
 func ListAction() Action {
 	return listAction
@@ -121,6 +153,21 @@ func ExecAction() Action {
 	return execAction
 }
 
+// CreateAction represents the create action
+func CreateAction() Action {
+	return createAction
+}
+
+// MkdirAction represents the mkdir action
+func MkdirAction() Action {
+	return mkdirAction
+}
+
+// RenameAction represents the rename action
+func RenameAction() Action {
+	return renameAction
+}
+
 // DeleteAction represents the delete action
 func DeleteAction() Action {
 	return deleteAction
@@ -131,6 +178,11 @@ func SignalAction() Action {
 	return signalAction
 }
 
+// ForwardAction represents the forward action
+func ForwardAction() Action {
+	return forwardAction
+}
+
 // Actions returns all of the available Wash actions as a map
 // of <action_name> => <action_object>.
 func Actions() map[string]Action {
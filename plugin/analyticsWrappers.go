@@ -21,6 +21,13 @@ func ReadWithAnalytics(ctx context.Context, e Entry, size int64, offset int64) (
 	return Read(ctx, e, size, offset)
 }
 
+// ReadStreamWithAnalytics is a wrapper to plugin.ReadStream. Use it when you need to report
+// a 'ReadStream' invocation to analytics. Otherwise, use plugin.ReadStream.
+func ReadStreamWithAnalytics(ctx context.Context, s SequentialReadable) (io.ReadCloser, error) {
+	submitMethodInvocation(ctx, s, "ReadStream")
+	return ReadStream(ctx, s)
+}
+
 // StreamWithAnalytics is a wrapper to s#Stream. Use it when you need to report a 'Stream'
 // invocation to analytics. Otherwise, use s#Stream.
 func StreamWithAnalytics(ctx context.Context, s Streamable) (io.ReadCloser, error) {
@@ -35,6 +42,38 @@ func WriteWithAnalytics(ctx context.Context, w Writable, b []byte) error {
 	return Write(ctx, w, b)
 }
 
+// WriteFromWithAnalytics is a wrapper to plugin.WriteFrom. Use it when you need to report a
+// 'Write' or 'WriteStream' invocation to analytics. Otherwise, use plugin.WriteFrom.
+func WriteFromWithAnalytics(ctx context.Context, w Writable, r io.Reader, size int64) error {
+	if _, ok := w.(StreamWritable); ok {
+		submitMethodInvocation(ctx, w, "WriteStream")
+	} else {
+		submitMethodInvocation(ctx, w, "Write")
+	}
+	return WriteFrom(ctx, w, r, size)
+}
+
+// CreateWithAnalytics is a wrapper to plugin.Create. Use it when you need to report a
+// 'Create' invocation to analytics. Otherwise, use plugin.Create.
+func CreateWithAnalytics(ctx context.Context, p Creatable, name string, content []byte) (Entry, error) {
+	submitMethodInvocation(ctx, p, "Create")
+	return Create(ctx, p, name, content)
+}
+
+// CreateDirWithAnalytics is a wrapper to plugin.CreateDir. Use it when you need to report a
+// 'CreateDir' invocation to analytics. Otherwise, use plugin.CreateDir.
+func CreateDirWithAnalytics(ctx context.Context, p DirCreatable, name string) (Parent, error) {
+	submitMethodInvocation(ctx, p, "CreateDir")
+	return CreateDir(ctx, p, name)
+}
+
+// RenameWithAnalytics is a wrapper to plugin.Rename. Use it when you need to report a
+// 'Rename' invocation to analytics. Otherwise, use plugin.Rename.
+func RenameWithAnalytics(ctx context.Context, r Renamable, newParent Parent, newName string) (Entry, error) {
+	submitMethodInvocation(ctx, r, "Rename")
+	return Rename(ctx, r, newParent, newName)
+}
+
 // ExecWithAnalytics is a wrapper to e#Exec. Use it when you need to report an 'Exec'
 // invocation to analytics. Otherwise, use e#Exec.
 func ExecWithAnalytics(ctx context.Context, e Execable, cmd string, args []string, opts ExecOptions) (ExecCommand, error) {
@@ -56,6 +95,13 @@ func DeleteWithAnalytics(ctx context.Context, d Deletable) (bool, error) {
 	return Delete(ctx, d)
 }
 
+// ForwardWithAnalytics is a wrapper to plugin.Forward. Use it when you need to report a
+// 'Forward' invocation to analytics. Otherwise, use plugin.Forward.
+func ForwardWithAnalytics(ctx context.Context, f Forwardable, ports []string, out io.Writer) error {
+	submitMethodInvocation(ctx, f, "Forward")
+	return Forward(ctx, f, ports, out)
+}
+
 func submitMethodInvocation(ctx context.Context, e Entry, method string) {
 	isCorePluginEntry := e.Schema() != nil
 	if !isCorePluginEntry {
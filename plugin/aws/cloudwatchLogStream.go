@@ -0,0 +1,130 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	logsClient "github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// cloudwatchLogStream represents a CloudWatch log stream
+type cloudwatchLogStream struct {
+	plugin.EntryBase
+	name  string
+	group *cloudwatchLogGroup
+}
+
+func newCloudwatchLogStream(stream *logsClient.LogStream, group *cloudwatchLogGroup) *cloudwatchLogStream {
+	name := awsSDK.StringValue(stream.LogStreamName)
+	logStream := &cloudwatchLogStream{
+		EntryBase: plugin.NewEntry(name),
+	}
+	logStream.name = name
+	logStream.group = group
+
+	crtime := msToTime(awsSDK.Int64Value(stream.CreationTime))
+	mtime := crtime
+	if stream.LastIngestionTime != nil {
+		mtime = msToTime(awsSDK.Int64Value(stream.LastIngestionTime))
+	}
+	logStream.
+		SetPartialMetadata(stream).
+		Attributes().
+		SetCrtime(crtime).
+		SetMtime(mtime)
+	return logStream
+}
+
+func (s *cloudwatchLogStream) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(s, "stream").
+		SetDescription(cloudwatchLogStreamDescription).
+		SetPartialMetadataSchema(logsClient.LogStream{})
+}
+
+// getEvents returns a page of events starting from the given token. If the token's empty,
+// it returns the most recent limit events.
+func (s *cloudwatchLogStream) getEvents(ctx context.Context, limit int64, token string) (*logsClient.GetLogEventsOutput, error) {
+	request := &logsClient.GetLogEventsInput{
+		LogGroupName:  awsSDK.String(s.group.name),
+		LogStreamName: awsSDK.String(s.name),
+		Limit:         awsSDK.Int64(limit),
+	}
+	if token != "" {
+		request.NextToken = awsSDK.String(token)
+		request.StartFromHead = awsSDK.Bool(true)
+	}
+	return s.group.client.GetLogEventsWithContext(ctx, request)
+}
+
+// Read returns the 10000 most recent events, which is the most a single request returns.
+func (s *cloudwatchLogStream) Read(ctx context.Context) ([]byte, error) {
+	resp, err := s.getEvents(ctx, 10000, "")
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Read %v events from log stream %v", len(resp.Events), s.name)
+	return formatLogEvents(resp.Events), nil
+}
+
+func (s *cloudwatchLogStream) Stream(ctx context.Context) (io.ReadCloser, error) {
+	// Start with the last 10 events, then poll for new ones.
+	resp, err := s.getEvents(ctx, 10, "")
+	if err != nil {
+		return nil, err
+	}
+
+	r, w := io.Pipe()
+	go func() {
+		token := awsSDK.StringValue(resp.NextForwardToken)
+		if _, err := w.Write(formatLogEvents(resp.Events)); err != nil {
+			return
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				w.CloseWithError(ctx.Err())
+				return
+			case <-time.After(2 * time.Second):
+			}
+
+			resp, err := s.getEvents(ctx, 100, token)
+			if err != nil {
+				w.CloseWithError(fmt.Errorf("unable to get new events from log stream %v: %w", s.name, err))
+				return
+			}
+			token = awsSDK.StringValue(resp.NextForwardToken)
+			if _, err := w.Write(formatLogEvents(resp.Events)); err != nil {
+				// The reader was closed.
+				return
+			}
+		}
+	}()
+	return r, nil
+}
+
+func formatLogEvents(events []*logsClient.OutputLogEvent) []byte {
+	var buf bytes.Buffer
+	for _, event := range events {
+		timestamp := msToTime(awsSDK.Int64Value(event.Timestamp)).UTC().Format(time.RFC3339)
+		message := awsSDK.StringValue(event.Message)
+		buf.WriteString(timestamp + " " + message)
+		if len(message) == 0 || message[len(message)-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes()
+}
+
+const cloudwatchLogStreamDescription = `
+This is a CloudWatch log stream. Each line is formatted as
+    TIME_UTC MESSAGE
+Reading it returns the 10000 most recent events. Streaming it starts with the
+10 most recent events, then polls for new ones every 2 seconds.
+`
@@ -0,0 +1,134 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	logsClient "github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// cloudwatchLogsDir represents the resources/logs directory
+type cloudwatchLogsDir struct {
+	plugin.EntryBase
+	client *logsClient.CloudWatchLogs
+}
+
+func newCloudwatchLogsDir(ctx context.Context, session *session.Session) *cloudwatchLogsDir {
+	logsDir := &cloudwatchLogsDir{
+		EntryBase: plugin.NewEntry("logs"),
+	}
+	logsDir.client = logsClient.New(session)
+	if _, err := plugin.List(ctx, logsDir); err != nil {
+		logsDir.MarkInaccessible(ctx, err)
+	}
+	return logsDir
+}
+
+func (l *cloudwatchLogsDir) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(l, "logs").
+		SetDescription(cloudwatchLogsDirDescription).
+		IsSingleton()
+}
+
+func (l *cloudwatchLogsDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&cloudwatchLogGroup{}).Schema(),
+	}
+}
+
+// List lists the log groups.
+func (l *cloudwatchLogsDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var entries []plugin.Entry
+	err := l.client.DescribeLogGroupsPagesWithContext(ctx, &logsClient.DescribeLogGroupsInput{}, func(page *logsClient.DescribeLogGroupsOutput, _ bool) bool {
+		for _, group := range page.LogGroups {
+			entries = append(entries, newCloudwatchLogGroup(group, l.client))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Listing %v CloudWatch log groups", len(entries))
+	return entries, nil
+}
+
+// maxLogStreams is the number of most recently active streams that we list per log group.
+// Groups like those of Lambda functions accumulate a stream per function instance.
+const maxLogStreams = 100
+
+// cloudwatchLogGroup represents a CloudWatch log group
+type cloudwatchLogGroup struct {
+	plugin.EntryBase
+	name   string
+	client *logsClient.CloudWatchLogs
+}
+
+func newCloudwatchLogGroup(group *logsClient.LogGroup, client *logsClient.CloudWatchLogs) *cloudwatchLogGroup {
+	// Log group names usually contain slashes, e.g. /aws/lambda/<function>. They're
+	// displayed with the default slash replacer.
+	name := awsSDK.StringValue(group.LogGroupName)
+	logGroup := &cloudwatchLogGroup{
+		EntryBase: plugin.NewEntry(name),
+	}
+	logGroup.name = name
+	logGroup.client = client
+
+	crtime := msToTime(awsSDK.Int64Value(group.CreationTime))
+	logGroup.
+		SetPartialMetadata(group).
+		Attributes().
+		SetCrtime(crtime).
+		SetMtime(crtime)
+	return logGroup
+}
+
+func (g *cloudwatchLogGroup) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(g, "group").
+		SetPartialMetadataSchema(logsClient.LogGroup{})
+}
+
+func (g *cloudwatchLogGroup) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&cloudwatchLogStream{}).Schema(),
+	}
+}
+
+func (g *cloudwatchLogGroup) List(ctx context.Context) ([]plugin.Entry, error) {
+	request := &logsClient.DescribeLogStreamsInput{
+		LogGroupName: awsSDK.String(g.name),
+		OrderBy:      awsSDK.String(logsClient.OrderByLastEventTime),
+		Descending:   awsSDK.Bool(true),
+	}
+	var entries []plugin.Entry
+	err := g.client.DescribeLogStreamsPagesWithContext(ctx, request, func(page *logsClient.DescribeLogStreamsOutput, _ bool) bool {
+		for _, stream := range page.LogStreams {
+			entries = append(entries, newCloudwatchLogStream(stream, g))
+			if len(entries) >= maxLogStreams {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Listing %v streams in log group %v", len(entries), g.name)
+	return entries, nil
+}
+
+// msToTime converts a CloudWatch Logs timestamp, which is the number of milliseconds
+// since the epoch, to a time.
+func msToTime(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
+
+const cloudwatchLogsDirDescription = `
+These are your CloudWatch log groups. Each group lists its 100 most recently
+active log streams, which can be read and streamed (e.g. with 'tail -f').
+`
@@ -12,17 +12,19 @@ import (
 // ec2Dir represents the resources/ec2 directory
 type ec2Dir struct {
 	plugin.EntryBase
-	session *session.Session
-	client  *ec2Client.EC2
+	session  *session.Session
+	client   *ec2Client.EC2
+	execOpts ec2ExecOptions
 }
 
-func newEC2Dir(session *session.Session) *ec2Dir {
+func newEC2Dir(session *session.Session, execOpts ec2ExecOptions) *ec2Dir {
 	ec2Dir := &ec2Dir{
 		EntryBase: plugin.NewEntry("ec2"),
 	}
 	ec2Dir.DisableDefaultCaching()
 	ec2Dir.session = session
 	ec2Dir.client = ec2Client.New(session)
+	ec2Dir.execOpts = execOpts
 	return ec2Dir
 }
 
@@ -37,5 +39,5 @@ func (e *ec2Dir) ChildSchemas() []*plugin.EntrySchema {
 }
 
 func (e *ec2Dir) List(ctx context.Context) ([]plugin.Entry, error) {
-	return []plugin.Entry{newEC2InstancesDir(ctx, e.session, e.client)}, nil
+	return []plugin.Entry{newEC2InstancesDir(ctx, e.session, e.client, e.execOpts)}, nil
 }
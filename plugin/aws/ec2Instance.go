@@ -15,6 +15,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	ec2Client "github.com/aws/aws-sdk-go/service/ec2"
+	ec2InstanceConnectClient "github.com/aws/aws-sdk-go/service/ec2instanceconnect"
 	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/plugin"
 	"github.com/puppetlabs/wash/transport"
@@ -27,6 +28,7 @@ type ec2Instance struct {
 	id                      string
 	session                 *session.Session
 	client                  *ec2Client.EC2
+	execOpts                ec2ExecOptions
 	latestConsoleOutputOnce sync.Once
 	hasLatestConsoleOutput  bool
 }
@@ -43,7 +45,7 @@ const (
 	EC2InstanceStopped           = 80
 )
 
-func newEC2Instance(ctx context.Context, inst *ec2Client.Instance, session *session.Session, client *ec2Client.EC2) *ec2Instance {
+func newEC2Instance(ctx context.Context, inst *ec2Client.Instance, session *session.Session, client *ec2Client.EC2, execOpts ec2ExecOptions) *ec2Instance {
 	id := awsSDK.StringValue(inst.InstanceId)
 	name := id
 	// AWS has a practice of using a tag with the key 'Name' as the display name in the console, so
@@ -62,6 +64,7 @@ func newEC2Instance(ctx context.Context, inst *ec2Client.Instance, session *sess
 	ec2Instance.id = id
 	ec2Instance.session = session
 	ec2Instance.client = client
+	ec2Instance.execOpts = execOpts
 
 	attributes, metadata := getAttributesAndMetadata(inst)
 	ec2Instance.
@@ -272,33 +275,55 @@ func (inst *ec2Instance) Delete(ctx context.Context) (bool, error) {
 	return false, inst.Signal(ctx, "terminate")
 }
 
+// Exec executes the command through SSM, WinRM or SSH depending on the profile's exec config.
+// With the default "auto" method, SSM is used if the instance is managed by SSM. Otherwise
+// WinRM is used for Windows instances and SSH for everything else.
 func (inst *ec2Instance) Exec(ctx context.Context, cmd string, args []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
-	// TBD: how to get WinRM connection info. Only work with Kerberos? Require a mini-inventory from wash.yaml?
-
-	meta, err := inst.Metadata(ctx)
-	if err != nil {
-		return nil, err
+	switch {
+	case inst.useSSM(ctx):
+		return inst.execSSM(ctx, cmd, args, opts)
+	case inst.useWinRM():
+		return inst.execWinRM(ctx, cmd, args, opts)
+	default:
+		return inst.execSSH(ctx, cmd, args, opts)
 	}
-	var hostname string
+}
+
+// hostname returns the address to connect to the instance with, preferring public addresses.
+func (inst *ec2Instance) hostname(ctx context.Context, meta plugin.JSONObject) (string, error) {
 	if name, ok := meta["PublicDnsName"]; ok && name != nil {
-		hostname = name.(string)
+		return name.(string), nil
 	} else if ipaddr, ok := meta["PublicIpAddress"]; ok && ipaddr != nil {
-		hostname = ipaddr.(string)
+		return ipaddr.(string), nil
 	} else if ipaddr, ok := meta["PrivateIpAddress"]; ok && ipaddr != nil {
-		hostname = ipaddr.(string)
-		activity.Record(ctx, "No public address was found for %v, trying private IP address %v", inst, hostname)
-	} else {
-		return nil, fmt.Errorf("No available interface found for %v", inst)
+		activity.Record(ctx, "No public address was found for %v, trying private IP address %v", inst, ipaddr)
+		return ipaddr.(string), nil
 	}
+	return "", fmt.Errorf("No available interface found for %v", inst)
+}
 
-	var identityfile string
+// keyFile returns the expected location of the private key for the instance's key pair.
+func (inst *ec2Instance) keyFile(ctx context.Context, meta plugin.JSONObject) string {
 	if keyname, ok := meta["KeyName"]; ok && keyname != nil {
 		if homedir, err := os.UserHomeDir(); err != nil {
 			activity.Record(ctx, "Cannot determine home directory for location of key file. But key name is "+keyname.(string)+" %v", err)
 		} else {
-			identityfile = (filepath.Join(homedir, ".ssh", (keyname.(string) + ".pem")))
+			return filepath.Join(homedir, ".ssh", (keyname.(string) + ".pem"))
 		}
 	}
+	return ""
+}
+
+func (inst *ec2Instance) execSSH(ctx context.Context, cmd string, args []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
+	meta, err := inst.Metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hostname, err := inst.hostname(ctx, meta)
+	if err != nil {
+		return nil, err
+	}
+	identityfile := inst.keyFile(ctx, meta)
 
 	var fallbackuser string
 	// Scan console output for user name instance was provisioned with. Set to ec2-user if not found
@@ -319,7 +344,41 @@ func (inst *ec2Instance) Exec(ctx context.Context, cmd string, args []string, op
 	//
 	// fallbackuser and identiyfile can be overridden in ~/.ssh/config.
 	//
-	return transport.ExecSSH(ctx, transport.Identity{Host: hostname, FallbackUser: fallbackuser, IdentityFile: identityfile}, append([]string{cmd}, args...), opts)
+	identity := transport.Identity{Host: hostname, FallbackUser: fallbackuser, IdentityFile: identityfile}
+
+	// Authorize an ephemeral key with EC2 Instance Connect so that we don't need the launch key.
+	// Windows instances don't support Instance Connect.
+	if placement, ok := meta["Placement"].(map[string]interface{}); ok && !inst.isWindows() {
+		if az, ok := placement["AvailabilityZone"].(string); ok && az != "" {
+			identity.PushKey = inst.instanceConnectKeyPusher(az)
+		}
+	}
+	return transport.ExecSSH(ctx, identity, append([]string{cmd}, args...), opts)
+}
+
+func (inst *ec2Instance) isWindows() bool {
+	return inst.Attributes().HasOS() && inst.Attributes().OS().LoginShell == plugin.PowerShell
+}
+
+// instanceConnectKeyPusher returns a function that pushes a public key to the instance with
+// EC2 Instance Connect. The key is authorized for 60 seconds.
+func (inst *ec2Instance) instanceConnectKeyPusher(availabilityZone string) transport.KeyPusher {
+	return func(ctx context.Context, user string, publicKey string) error {
+		client := ec2InstanceConnectClient.New(inst.session)
+		resp, err := client.SendSSHPublicKeyWithContext(ctx, &ec2InstanceConnectClient.SendSSHPublicKeyInput{
+			AvailabilityZone: awsSDK.String(availabilityZone),
+			InstanceId:       awsSDK.String(inst.id),
+			InstanceOSUser:   awsSDK.String(user),
+			SSHPublicKey:     awsSDK.String(publicKey),
+		})
+		if err != nil {
+			return err
+		}
+		if !awsSDK.BoolValue(resp.Success) {
+			return fmt.Errorf("EC2 Instance Connect did not accept the key for %v@%v", user, inst.id)
+		}
+		return nil
+	}
 }
 
 func (inst *ec2Instance) Signal(ctx context.Context, signal string) error {
@@ -353,7 +412,25 @@ func (inst *ec2Instance) Signal(ctx context.Context, signal string) error {
 }
 
 const ec2InstanceDescription = `
-This is an EC2 instance. Its Exec action uses SSH. It will look up port, user,
+This is an EC2 instance. Its Exec action uses SSM if the instance is managed by
+SSM, WinRM if it's a Windows instance, and SSH otherwise; see the AWS plugin
+root's description for how to choose a method and configure WinRM per profile.
+
+SSM commands are run with SendCommand using the AWS-RunShellScript or
+AWS-RunPowerShellScript documents. Their output is returned once the command
+finishes and is truncated to SSM's limit of 24000 characters for stdout and
+8000 characters for stderr. Stdin is limited to 32KiB on Linux instances and is
+unsupported on Windows instances.
+
+WinRM connects to Windows instances over HTTP or HTTPS with NTLM or basic
+authentication. Commands are run as PowerShell scripts, and their exit code is
+the exit code of the last failed native command (or 1 if a cmdlet failed).
+Unless a password is configured, Wash decrypts the instance's Administrator
+password with ~/.ssh/<KeyName>.pem.
+
+SSH authenticates with an ephemeral key that's pushed to the instance with EC2
+Instance Connect, falling back to ~/.ssh/<KeyName>.pem and the SSH agent if the
+instance doesn't support Instance Connect. SSH will look up port, user,
 and other configuration by exact hostname match from default SSH config files.
 If present, a local SSH agent will be used for authentication. Lots of SSH
 configuration is currently omitted, such as global known hosts files, finding
@@ -0,0 +1,189 @@
+package aws
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	ssmClient "github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/kballard/go-shellquote"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// maxSSMStdin is the most stdin we'll embed in an SSM command. SendCommand limits the
+// size of its parameters, so larger input should be copied some other way.
+const maxSSMStdin = 32 * 1024
+
+// ssmPollInterval is how often we check whether an SSM command has finished.
+const ssmPollInterval = 1 * time.Second
+
+// useSSM returns whether commands should be executed on the instance through SSM.
+func (inst *ec2Instance) useSSM(ctx context.Context) bool {
+	switch inst.execOpts.method {
+	case "ssm":
+		return true
+	case "ssh":
+		return false
+	}
+
+	managed, err := plugin.CachedOp(ctx, "SSMManaged", inst, 1*time.Minute, func() (interface{}, error) {
+		resp, err := inst.ssmClient().DescribeInstanceInformationWithContext(ctx, &ssmClient.DescribeInstanceInformationInput{
+			Filters: []*ssmClient.InstanceInformationStringFilter{
+				{
+					Key:    awsSDK.String(ssmClient.InstanceInformationFilterKeyInstanceIds),
+					Values: awsSDK.StringSlice([]string{inst.id}),
+				},
+			},
+		})
+		if err != nil {
+			return false, err
+		}
+		for _, info := range resp.InstanceInformationList {
+			if awsSDK.StringValue(info.PingStatus) == ssmClient.PingStatusOnline {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		activity.Record(ctx, "Unable to determine whether %v is managed by SSM, falling back to SSH: %v", inst, err)
+		return false
+	}
+	return managed.(bool)
+}
+
+func (inst *ec2Instance) ssmClient() *ssmClient.SSM {
+	return ssmClient.New(inst.session)
+}
+
+// execSSM runs the command with SSM's SendCommand API. The command's output is only available
+// once it finishes, so it's written to the output streams all at once.
+func (inst *ec2Instance) execSSM(ctx context.Context, cmd string, args []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
+	windows := inst.isWindows()
+	command, err := ssmCommand(append([]string{cmd}, args...), opts.Stdin, windows)
+	if err != nil {
+		return nil, err
+	}
+
+	document := "AWS-RunShellScript"
+	if windows {
+		document = "AWS-RunPowerShellScript"
+	}
+
+	client := inst.ssmClient()
+	resp, err := client.SendCommandWithContext(ctx, &ssmClient.SendCommandInput{
+		DocumentName: awsSDK.String(document),
+		InstanceIds:  awsSDK.StringSlice([]string{inst.id}),
+		Parameters: map[string][]*string{
+			"commands": awsSDK.StringSlice([]string{command}),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	commandID := awsSDK.StringValue(resp.Command.CommandId)
+	activity.Record(ctx, "Sent SSM command %v to %v: %v", commandID, inst, command)
+
+	execCmd := plugin.NewExecCommand(ctx)
+	done := make(chan struct{})
+	execCmd.SetStopFunc(func() {
+		select {
+		case <-done:
+			// The command finished, so there's nothing to cancel.
+		default:
+			_, err := client.CancelCommandWithContext(context.Background(), &ssmClient.CancelCommandInput{
+				CommandId:   awsSDK.String(commandID),
+				InstanceIds: awsSDK.StringSlice([]string{inst.id}),
+			})
+			activity.Record(ctx, "Cancelled SSM command %v on context termination: %v", commandID, err)
+		}
+	})
+
+	go func() {
+		defer close(done)
+		invocation, err := waitForSSMCommand(ctx, client, commandID, inst.id)
+		if err != nil {
+			execCmd.CloseStreamsWithError(err)
+			execCmd.SetExitCodeErr(err)
+			return
+		}
+
+		_, err = execCmd.Stdout().Write([]byte(awsSDK.StringValue(invocation.StandardOutputContent)))
+		if err == nil {
+			_, err = execCmd.Stderr().Write([]byte(awsSDK.StringValue(invocation.StandardErrorContent)))
+		}
+		execCmd.CloseStreamsWithError(err)
+
+		status := awsSDK.StringValue(invocation.Status)
+		activity.Record(ctx, "SSM command %v on %v finished with status %v", commandID, inst, status)
+		switch status {
+		case ssmClient.CommandInvocationStatusSuccess, ssmClient.CommandInvocationStatusFailed:
+			execCmd.SetExitCode(int(awsSDK.Int64Value(invocation.ResponseCode)))
+		default:
+			execCmd.SetExitCodeErr(fmt.Errorf("SSM command %v did not complete: %v", commandID, status))
+		}
+	}()
+
+	return execCmd, nil
+}
+
+// ssmCommand builds the script that SSM runs. Stdin is embedded in the script as base64
+// and piped to the command.
+func ssmCommand(cmd []string, stdin io.Reader, windows bool) (string, error) {
+	command := shellquote.Join(cmd...)
+	if stdin == nil {
+		return command, nil
+	}
+	if windows {
+		return "", fmt.Errorf("stdin is not supported when executing commands on Windows instances through SSM")
+	}
+
+	input, err := ioutil.ReadAll(io.LimitReader(stdin, maxSSMStdin+1))
+	if err != nil {
+		return "", err
+	}
+	if len(input) > maxSSMStdin {
+		return "", fmt.Errorf("stdin is limited to %v bytes when executing commands through SSM", maxSSMStdin)
+	}
+	return fmt.Sprintf("echo %v | base64 -d | %v", base64.StdEncoding.EncodeToString(input), command), nil
+}
+
+// waitForSSMCommand polls the command's invocation on the instance until it finishes.
+func waitForSSMCommand(ctx context.Context, client *ssmClient.SSM, commandID, instanceID string) (*ssmClient.GetCommandInvocationOutput, error) {
+	request := &ssmClient.GetCommandInvocationInput{
+		CommandId:  awsSDK.String(commandID),
+		InstanceId: awsSDK.String(instanceID),
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(ssmPollInterval):
+		}
+
+		invocation, err := client.GetCommandInvocationWithContext(ctx, request)
+		if err != nil {
+			// The invocation may not exist immediately after the command's sent.
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == ssmClient.ErrCodeInvocationDoesNotExist {
+				continue
+			}
+			return nil, err
+		}
+
+		switch awsSDK.StringValue(invocation.Status) {
+		case ssmClient.CommandInvocationStatusPending,
+			ssmClient.CommandInvocationStatusInProgress,
+			ssmClient.CommandInvocationStatusDelayed,
+			ssmClient.CommandInvocationStatusCancelling:
+			continue
+		default:
+			return invocation, nil
+		}
+	}
+}
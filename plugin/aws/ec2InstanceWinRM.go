@@ -0,0 +1,112 @@
+package aws
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	ec2Client "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/transport"
+	"golang.org/x/crypto/ssh"
+)
+
+// useWinRM returns whether commands should be executed on the instance over WinRM.
+func (inst *ec2Instance) useWinRM() bool {
+	switch inst.execOpts.method {
+	case "winrm":
+		return true
+	case "auto":
+		return inst.isWindows()
+	default:
+		return false
+	}
+}
+
+func (inst *ec2Instance) execWinRM(ctx context.Context, cmd string, args []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
+	meta, err := inst.Metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hostname, err := inst.hostname(ctx, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	winrmOpts := inst.execOpts.winrm
+	identity := transport.WinRMIdentity{
+		Host:     hostname,
+		Port:     winrmOpts.port,
+		HTTPS:    winrmOpts.https,
+		Insecure: winrmOpts.insecure,
+		Basic:    winrmOpts.basic,
+		User:     winrmOpts.user,
+		Password: winrmOpts.password,
+	}
+	if identity.User == "" {
+		identity.User = "Administrator"
+	}
+	if identity.Password == "" {
+		if identity.Password, err = inst.administratorPassword(ctx, inst.keyFile(ctx, meta)); err != nil {
+			return nil, err
+		}
+	}
+	return transport.ExecWinRM(ctx, identity, append([]string{cmd}, args...), opts)
+}
+
+// administratorPassword decrypts the instance's initial Administrator password with the
+// private key of its key pair.
+func (inst *ec2Instance) administratorPassword(ctx context.Context, keyFile string) (string, error) {
+	password, err := plugin.CachedOp(ctx, "AdministratorPassword", inst, 1*time.Hour, func() (interface{}, error) {
+		if keyFile == "" {
+			return nil, fmt.Errorf("%v has no key pair to decrypt its password with; configure a WinRM password instead", inst.id)
+		}
+		pemBytes, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the key pair to decrypt the password of %v: %v", inst.id, err)
+		}
+
+		resp, err := inst.client.GetPasswordDataWithContext(ctx, &ec2Client.GetPasswordDataInput{
+			InstanceId: awsSDK.String(inst.id),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return decryptPasswordData(awsSDK.StringValue(resp.PasswordData), pemBytes)
+	})
+	if err != nil {
+		return "", err
+	}
+	return password.(string), nil
+}
+
+// decryptPasswordData decrypts the base64-encoded password data returned by GetPasswordData.
+func decryptPasswordData(passwordData string, pemBytes []byte) (string, error) {
+	if passwordData == "" {
+		return "", fmt.Errorf("the password isn't available yet; Windows instances make it available a few minutes after launch")
+	}
+	encrypted, err := base64.StdEncoding.DecodeString(passwordData)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode the password data: %v", err)
+	}
+
+	key, err := ssh.ParseRawPrivateKey(pemBytes)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse the key pair: %v", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return "", fmt.Errorf("the key pair must be an RSA key to decrypt the password, not %T", key)
+	}
+
+	password, err := rsa.DecryptPKCS1v15(rand.Reader, rsaKey, encrypted)
+	if err != nil {
+		return "", fmt.Errorf("unable to decrypt the password: %v", err)
+	}
+	return string(password), nil
+}
@@ -17,16 +17,18 @@ import (
 // No need to do this now since there's no clear use-case for it yet.
 type ec2InstancesDir struct {
 	plugin.EntryBase
-	session *session.Session
-	client  *ec2Client.EC2
+	session  *session.Session
+	client   *ec2Client.EC2
+	execOpts ec2ExecOptions
 }
 
-func newEC2InstancesDir(ctx context.Context, session *session.Session, client *ec2Client.EC2) *ec2InstancesDir {
+func newEC2InstancesDir(ctx context.Context, session *session.Session, client *ec2Client.EC2, execOpts ec2ExecOptions) *ec2InstancesDir {
 	ec2InstancesDir := &ec2InstancesDir{
 		EntryBase: plugin.NewEntry("instances"),
 	}
 	ec2InstancesDir.session = session
 	ec2InstancesDir.client = client
+	ec2InstancesDir.execOpts = execOpts
 	if _, err := plugin.List(ctx, ec2InstancesDir); err != nil {
 		ec2InstancesDir.MarkInaccessible(ctx, err)
 	}
@@ -67,6 +69,7 @@ func (is *ec2InstancesDir) List(ctx context.Context) ([]plugin.Entry, error) {
 				instance,
 				is.session,
 				is.client,
+				is.execOpts,
 			)
 		}
 
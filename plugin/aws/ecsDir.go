@@ -0,0 +1,124 @@
+package aws
+
+import (
+	"context"
+	"strings"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	ecsClient "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// ecsDir represents the resources/ecs directory
+type ecsDir struct {
+	plugin.EntryBase
+	client *ecsClient.ECS
+}
+
+func newECSDir(ctx context.Context, session *session.Session) *ecsDir {
+	ecsDir := &ecsDir{
+		EntryBase: plugin.NewEntry("ecs"),
+	}
+	ecsDir.client = ecsClient.New(session)
+	if _, err := plugin.List(ctx, ecsDir); err != nil {
+		ecsDir.MarkInaccessible(ctx, err)
+	}
+	return ecsDir
+}
+
+func (e *ecsDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(e, "ecs").IsSingleton()
+}
+
+func (e *ecsDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&ecsCluster{}).Schema(),
+	}
+}
+
+// List lists the clusters.
+func (e *ecsDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var arns []*string
+	err := e.client.ListClustersPagesWithContext(ctx, &ecsClient.ListClustersInput{}, func(page *ecsClient.ListClustersOutput, _ bool) bool {
+		arns = append(arns, page.ClusterArns...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []plugin.Entry
+	// DescribeClusters accepts at most 100 clusters at a time.
+	for _, batch := range batchARNs(arns, 100) {
+		resp, err := e.client.DescribeClustersWithContext(ctx, &ecsClient.DescribeClustersInput{
+			Clusters: batch,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, cluster := range resp.Clusters {
+			entries = append(entries, newECSCluster(cluster, e.client))
+		}
+	}
+	activity.Record(ctx, "Listing %v ECS clusters", len(entries))
+	return entries, nil
+}
+
+// batchARNs splits arns into batches of at most size ARNs. ECS's Describe* APIs limit
+// how many resources can be described in a single request.
+func batchARNs(arns []*string, size int) [][]*string {
+	var batches [][]*string
+	for len(arns) > size {
+		batches = append(batches, arns[:size])
+		arns = arns[size:]
+	}
+	if len(arns) > 0 {
+		batches = append(batches, arns)
+	}
+	return batches
+}
+
+// ecsResourceID returns the last segment of an ECS ARN, e.g. the task ID of
+// arn:aws:ecs:us-west-2:123456789012:task/cluster/1234abcd.
+func ecsResourceID(arn string) string {
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
+
+// ecsCluster represents an ECS cluster
+type ecsCluster struct {
+	plugin.EntryBase
+	arn    string
+	client *ecsClient.ECS
+}
+
+func newECSCluster(cluster *ecsClient.Cluster, client *ecsClient.ECS) *ecsCluster {
+	ecsCluster := &ecsCluster{
+		EntryBase: plugin.NewEntry(awsSDK.StringValue(cluster.ClusterName)),
+	}
+	ecsCluster.arn = awsSDK.StringValue(cluster.ClusterArn)
+	ecsCluster.client = client
+	ecsCluster.SetPartialMetadata(cluster)
+	return ecsCluster
+}
+
+func (c *ecsCluster) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(c, "cluster").
+		SetPartialMetadataSchema(ecsClient.Cluster{})
+}
+
+func (c *ecsCluster) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&ecsServicesDir{}).Schema(),
+		(&ecsTasksDir{}).Schema(),
+	}
+}
+
+func (c *ecsCluster) List(ctx context.Context) ([]plugin.Entry, error) {
+	return []plugin.Entry{
+		newECSServicesDir(c),
+		newECSTasksDir(c),
+	}, nil
+}
@@ -0,0 +1,88 @@
+package aws
+
+import (
+	"context"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	ecsClient "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// ecsServicesDir represents the <cluster>/services directory
+type ecsServicesDir struct {
+	plugin.EntryBase
+	cluster *ecsCluster
+}
+
+func newECSServicesDir(cluster *ecsCluster) *ecsServicesDir {
+	servicesDir := &ecsServicesDir{
+		EntryBase: plugin.NewEntry("services"),
+	}
+	servicesDir.cluster = cluster
+	return servicesDir
+}
+
+func (s *ecsServicesDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(s, "services").IsSingleton()
+}
+
+func (s *ecsServicesDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&ecsService{}).Schema(),
+	}
+}
+
+func (s *ecsServicesDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	client := s.cluster.client
+	var arns []*string
+	request := &ecsClient.ListServicesInput{Cluster: awsSDK.String(s.cluster.arn)}
+	err := client.ListServicesPagesWithContext(ctx, request, func(page *ecsClient.ListServicesOutput, _ bool) bool {
+		arns = append(arns, page.ServiceArns...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []plugin.Entry
+	// DescribeServices accepts at most 10 services at a time.
+	for _, batch := range batchARNs(arns, 10) {
+		resp, err := client.DescribeServicesWithContext(ctx, &ecsClient.DescribeServicesInput{
+			Cluster:  awsSDK.String(s.cluster.arn),
+			Services: batch,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, service := range resp.Services {
+			entries = append(entries, newECSService(service))
+		}
+	}
+	activity.Record(ctx, "Listing %v services in ECS cluster %v", len(entries), s.cluster.Name())
+	return entries, nil
+}
+
+// ecsService represents an ECS service
+type ecsService struct {
+	plugin.EntryBase
+}
+
+func newECSService(service *ecsClient.Service) *ecsService {
+	ecsService := &ecsService{
+		EntryBase: plugin.NewEntry(awsSDK.StringValue(service.ServiceName)),
+	}
+	crtime := awsSDK.TimeValue(service.CreatedAt)
+	ecsService.
+		SetPartialMetadata(service).
+		Attributes().
+		SetCrtime(crtime).
+		SetMtime(crtime)
+	return ecsService
+}
+
+func (s *ecsService) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(s, "service").
+		SetPartialMetadataSchema(ecsClient.Service{})
+}
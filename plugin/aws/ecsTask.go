@@ -0,0 +1,210 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	ecsClient "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/kballard/go-shellquote"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// ecsTasksDir represents the <cluster>/tasks directory
+type ecsTasksDir struct {
+	plugin.EntryBase
+	cluster *ecsCluster
+}
+
+func newECSTasksDir(cluster *ecsCluster) *ecsTasksDir {
+	tasksDir := &ecsTasksDir{
+		EntryBase: plugin.NewEntry("tasks"),
+	}
+	tasksDir.cluster = cluster
+	return tasksDir
+}
+
+func (t *ecsTasksDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(t, "tasks").IsSingleton()
+}
+
+func (t *ecsTasksDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&ecsTask{}).Schema(),
+	}
+}
+
+func (t *ecsTasksDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	client := t.cluster.client
+	var arns []*string
+	request := &ecsClient.ListTasksInput{Cluster: awsSDK.String(t.cluster.arn)}
+	err := client.ListTasksPagesWithContext(ctx, request, func(page *ecsClient.ListTasksOutput, _ bool) bool {
+		arns = append(arns, page.TaskArns...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []plugin.Entry
+	// DescribeTasks accepts at most 100 tasks at a time.
+	for _, batch := range batchARNs(arns, 100) {
+		resp, err := client.DescribeTasksWithContext(ctx, &ecsClient.DescribeTasksInput{
+			Cluster: awsSDK.String(t.cluster.arn),
+			Tasks:   batch,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range resp.Tasks {
+			entries = append(entries, newECSTask(task, t.cluster))
+		}
+	}
+	activity.Record(ctx, "Listing %v tasks in ECS cluster %v", len(entries), t.cluster.Name())
+	return entries, nil
+}
+
+// ecsTask represents an ECS task
+type ecsTask struct {
+	plugin.EntryBase
+	arn        string
+	cluster    *ecsCluster
+	containers []*ecsClient.Container
+}
+
+func newECSTask(task *ecsClient.Task, cluster *ecsCluster) *ecsTask {
+	arn := awsSDK.StringValue(task.TaskArn)
+	ecsTask := &ecsTask{
+		EntryBase: plugin.NewEntry(ecsResourceID(arn)),
+	}
+	ecsTask.arn = arn
+	ecsTask.cluster = cluster
+	ecsTask.containers = task.Containers
+
+	crtime := awsSDK.TimeValue(task.CreatedAt)
+	mtime := crtime
+	if task.StartedAt != nil {
+		mtime = awsSDK.TimeValue(task.StartedAt)
+	}
+	if task.StoppedAt != nil {
+		mtime = awsSDK.TimeValue(task.StoppedAt)
+	}
+	ecsTask.
+		SetPartialMetadata(task).
+		Attributes().
+		SetCrtime(crtime).
+		SetMtime(mtime)
+	return ecsTask
+}
+
+func (t *ecsTask) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(t, "task").
+		SetDescription(ecsTaskDescription).
+		SetPartialMetadataSchema(ecsClient.Task{}).
+		AddSignal("stop", "Stops the task")
+}
+
+// sessionManagerPlugin is the program that the AWS CLI uses to connect to Session Manager
+// sessions, including ECS Exec's.
+const sessionManagerPlugin = "session-manager-plugin"
+
+// Exec runs the command in the task's first container with ECS Exec. Like the AWS CLI, it
+// connects to the command's session with the Session Manager plugin, which implements
+// Session Manager's streaming protocol.
+func (t *ecsTask) Exec(ctx context.Context, cmd string, args []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
+	if len(t.containers) == 0 {
+		return nil, fmt.Errorf("task %v has no containers", t.Name())
+	}
+	container := t.containers[0]
+	pluginPath, err := exec.LookPath(sessionManagerPlugin)
+	if err != nil {
+		return nil, fmt.Errorf("exec on ECS tasks requires the Session Manager plugin for the AWS CLI: %v", err)
+	}
+
+	command := shellquote.Join(append([]string{cmd}, args...)...)
+	client := t.cluster.client
+	resp, err := client.ExecuteCommandWithContext(ctx, &ecsClient.ExecuteCommandInput{
+		Cluster:   awsSDK.String(t.cluster.arn),
+		Task:      awsSDK.String(t.arn),
+		Container: container.Name,
+		Command:   awsSDK.String(command),
+		// ECS Exec only supports interactive sessions.
+		Interactive: awsSDK.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Started ECS Exec session %v in %v/%v: %v",
+		awsSDK.StringValue(resp.Session.SessionId), t.Name(), awsSDK.StringValue(container.Name), command)
+
+	session, err := json.Marshal(resp.Session)
+	if err != nil {
+		return nil, err
+	}
+	target := fmt.Sprintf("ecs:%v_%v_%v", t.cluster.Name(), ecsResourceID(t.arn), awsSDK.StringValue(container.RuntimeId))
+	params, err := json.Marshal(map[string]string{"Target": target})
+	if err != nil {
+		return nil, err
+	}
+
+	// These are the arguments that the AWS CLI passes. The profile's only used to terminate
+	// the session, which is done by interrupting the plugin instead, so it's left empty.
+	region := awsSDK.StringValue(client.Config.Region)
+	proc := exec.Command(pluginPath, string(session), region, "StartSession", "", string(params), client.Endpoint)
+	execCmd := plugin.NewExecCommand(ctx)
+	proc.Stdin = opts.Stdin
+	proc.Stdout = execCmd.Stdout()
+	proc.Stderr = execCmd.Stderr()
+	if err := proc.Start(); err != nil {
+		return nil, err
+	}
+	execCmd.SetStopFunc(func() {
+		// The plugin terminates the session when it's interrupted.
+		err := proc.Process.Signal(os.Interrupt)
+		activity.Record(ctx, "Interrupted the ECS Exec session in %v on context termination: %v", t.Name(), err)
+	})
+
+	go func() {
+		err := proc.Wait()
+		activity.Record(ctx, "ECS Exec session in %v complete: %v", t.Name(), err)
+		execCmd.CloseStreamsWithError(nil)
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			execCmd.SetExitCode(exitErr.ExitCode())
+		} else if err != nil {
+			execCmd.SetExitCodeErr(err)
+		} else {
+			execCmd.SetExitCode(0)
+		}
+	}()
+	return execCmd, nil
+}
+
+func (t *ecsTask) Signal(ctx context.Context, signal string) error {
+	switch signal {
+	case "stop":
+		_, err := t.cluster.client.StopTaskWithContext(ctx, &ecsClient.StopTaskInput{
+			Cluster: awsSDK.String(t.cluster.arn),
+			Task:    awsSDK.String(t.arn),
+			Reason:  awsSDK.String("Stopped by Wash"),
+		})
+		return err
+	default:
+		return fmt.Errorf("unknown signal %v", signal)
+	}
+}
+
+const ecsTaskDescription = `
+This is an ECS task. It's named after its task ID. Sending it the stop signal
+stops the task.
+
+Exec runs commands in the task's first container with ECS Exec, which must be
+enabled on the task. Like the AWS CLI, it requires the Session Manager plugin
+(session-manager-plugin) to be installed. ECS Exec sessions always use a TTY,
+so the command's stderr is included in its stdout, and the exit code is the
+session's rather than the command's.
+`
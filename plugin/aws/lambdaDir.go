@@ -0,0 +1,55 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	lambdaClient "github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// lambdaDir represents the resources/lambda directory
+type lambdaDir struct {
+	plugin.EntryBase
+	client      *lambdaClient.Lambda
+	invocations *lambdaInvocations
+}
+
+func newLambdaDir(ctx context.Context, session *session.Session, invocations *lambdaInvocations) *lambdaDir {
+	lambdaDir := &lambdaDir{
+		EntryBase: plugin.NewEntry("lambda"),
+	}
+	lambdaDir.client = lambdaClient.New(session)
+	lambdaDir.invocations = invocations
+	if _, err := plugin.List(ctx, lambdaDir); err != nil {
+		lambdaDir.MarkInaccessible(ctx, err)
+	}
+	return lambdaDir
+}
+
+func (l *lambdaDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(l, "lambda").IsSingleton()
+}
+
+func (l *lambdaDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&lambdaFunction{}).Schema(),
+	}
+}
+
+// List lists the functions.
+func (l *lambdaDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var entries []plugin.Entry
+	err := l.client.ListFunctionsPagesWithContext(ctx, &lambdaClient.ListFunctionsInput{}, func(page *lambdaClient.ListFunctionsOutput, _ bool) bool {
+		for _, fn := range page.Functions {
+			entries = append(entries, newLambdaFunction(fn, l.client, l.invocations))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Listing %v Lambda functions", len(entries))
+	return entries, nil
+}
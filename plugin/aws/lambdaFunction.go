@@ -0,0 +1,70 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	lambdaClient "github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// lambdaFunction represents a Lambda function
+type lambdaFunction struct {
+	plugin.EntryBase
+	arn         string
+	codeSize    int64
+	client      *lambdaClient.Lambda
+	invocations *lambdaInvocations
+}
+
+// lambdaTimeFormat is the format of timestamps returned by the Lambda API,
+// e.g. 2019-10-14T18:10:28.523+0000.
+const lambdaTimeFormat = "2006-01-02T15:04:05.000-0700"
+
+func newLambdaFunction(fn *lambdaClient.FunctionConfiguration, client *lambdaClient.Lambda, invocations *lambdaInvocations) *lambdaFunction {
+	lambdaFn := &lambdaFunction{
+		EntryBase: plugin.NewEntry(awsSDK.StringValue(fn.FunctionName)),
+	}
+	lambdaFn.arn = awsSDK.StringValue(fn.FunctionArn)
+	lambdaFn.codeSize = awsSDK.Int64Value(fn.CodeSize)
+	lambdaFn.client = client
+	lambdaFn.invocations = invocations
+
+	lambdaFn.SetPartialMetadata(fn)
+	if mtime, err := time.Parse(lambdaTimeFormat, awsSDK.StringValue(fn.LastModified)); err == nil {
+		lambdaFn.
+			Attributes().
+			SetMtime(mtime).
+			SetCtime(mtime)
+	}
+
+	return lambdaFn
+}
+
+func (fn *lambdaFunction) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(fn, "function").
+		SetDescription(lambdaFunctionDescription).
+		SetPartialMetadataSchema(lambdaClient.FunctionConfiguration{})
+}
+
+func (fn *lambdaFunction) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&lambdaFunctionCode{}).Schema(),
+		(&lambdaFunctionInvoke{}).Schema(),
+	}
+}
+
+func (fn *lambdaFunction) List(ctx context.Context) ([]plugin.Entry, error) {
+	return []plugin.Entry{
+		newLambdaFunctionCode(fn),
+		newLambdaFunctionInvoke(fn),
+	}, nil
+}
+
+const lambdaFunctionDescription = `
+This is a Lambda function. Its metadata is the function's configuration. Its
+deployment package can be read from code.zip, and writing a JSON payload to
+invoke synchronously invokes the function.
+`
@@ -0,0 +1,73 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	lambdaClient "github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// lambdaFunctionCode represents a Lambda function's deployment package
+type lambdaFunctionCode struct {
+	plugin.EntryBase
+	fn *lambdaFunction
+}
+
+func newLambdaFunctionCode(fn *lambdaFunction) *lambdaFunctionCode {
+	code := &lambdaFunctionCode{
+		EntryBase: plugin.NewEntry("code.zip"),
+	}
+	code.fn = fn
+	code.Attributes().SetSize(uint64(fn.codeSize))
+	return code
+}
+
+func (c *lambdaFunctionCode) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(c, "code.zip").
+		SetDescription(lambdaFunctionCodeDescription).
+		IsSingleton()
+}
+
+func (c *lambdaFunctionCode) Read(ctx context.Context) ([]byte, error) {
+	resp, err := c.fn.client.GetFunctionWithContext(ctx, &lambdaClient.GetFunctionInput{
+		FunctionName: awsSDK.String(c.fn.arn),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Code == nil || resp.Code.Location == nil {
+		return nil, fmt.Errorf("the code for %v is not available for download", c.fn.Name())
+	}
+
+	// The location is a pre-signed S3 URL that's valid for 10 minutes.
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, awsSDK.StringValue(resp.Code.Location), nil)
+	if err != nil {
+		return nil, err
+	}
+	httpResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to download the code for %v: %v", c.fn.Name(), httpResp.Status)
+	}
+
+	content, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Downloaded %v bytes of code for %v", len(content), c.fn.Name())
+	return content, nil
+}
+
+const lambdaFunctionCodeDescription = `
+This is the function's deployment package. Reading it downloads the zip file
+that was uploaded for the function's latest version.
+`
@@ -0,0 +1,100 @@
+package aws
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sync"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	lambdaClient "github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// lambdaInvocations stores the response of each function's most recent invocation,
+// keyed by the function's ARN. Function entries are recreated whenever their parent
+// is re-listed, so the plugin root owns it and passes it down to them.
+type lambdaInvocations struct {
+	mux      sync.Mutex
+	payloads map[string][]byte
+}
+
+func newLambdaInvocations() *lambdaInvocations {
+	return &lambdaInvocations{payloads: make(map[string][]byte)}
+}
+
+func (l *lambdaInvocations) load(arn string) []byte {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	return l.payloads[arn]
+}
+
+func (l *lambdaInvocations) store(arn string, payload []byte) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.payloads[arn] = payload
+}
+
+// lambdaFunctionInvoke represents a file that invokes a Lambda function when
+// it's written to.
+type lambdaFunctionInvoke struct {
+	plugin.EntryBase
+	fn *lambdaFunction
+}
+
+func newLambdaFunctionInvoke(fn *lambdaFunction) *lambdaFunctionInvoke {
+	invoke := &lambdaFunctionInvoke{
+		EntryBase: plugin.NewEntry("invoke"),
+	}
+	invoke.fn = fn
+	invoke.DisableCachingFor(plugin.ReadOp)
+	return invoke
+}
+
+func (i *lambdaFunctionInvoke) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(i, "invoke").
+		SetDescription(lambdaFunctionInvokeDescription).
+		IsSingleton()
+}
+
+// Read returns the response of the most recent invocation made through Wash.
+func (i *lambdaFunctionInvoke) Read(ctx context.Context) ([]byte, error) {
+	if payload := i.fn.invocations.load(i.fn.arn); payload != nil {
+		return payload, nil
+	}
+	return []byte{}, nil
+}
+
+// Write synchronously invokes the function with the given payload.
+func (i *lambdaFunctionInvoke) Write(ctx context.Context, payload []byte) error {
+	resp, err := i.fn.client.InvokeWithContext(ctx, &lambdaClient.InvokeInput{
+		FunctionName: awsSDK.String(i.fn.arn),
+		Payload:      payload,
+		LogType:      awsSDK.String(lambdaClient.LogTypeTail),
+	})
+	if err != nil {
+		return err
+	}
+
+	if logs, err := base64.StdEncoding.DecodeString(awsSDK.StringValue(resp.LogResult)); err == nil {
+		activity.Record(ctx, "Invoked %v, log tail:\n%s", i.fn.Name(), logs)
+	}
+	i.fn.invocations.store(i.fn.arn, resp.Payload)
+
+	if resp.FunctionError != nil {
+		return fmt.Errorf("%v failed with a %v error: %s", i.fn.Name(), awsSDK.StringValue(resp.FunctionError), resp.Payload)
+	}
+	return nil
+}
+
+const lambdaFunctionInvokeDescription = `
+Writing a JSON payload to this file synchronously invokes the function with it,
+e.g.
+
+  echo '{"key": "value"}' > invoke
+
+Reading it returns the response of the most recent invocation made through Wash.
+If the function fails, the write fails with the function's error.
+`
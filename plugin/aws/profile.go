@@ -19,7 +19,7 @@ type profile struct {
 	resourcesDir []plugin.Entry
 }
 
-func newProfile(ctx context.Context, name string) (*profile, error) {
+func newProfile(ctx context.Context, name string, execOpts ec2ExecOptions, s3Opts s3Options, invocations *lambdaInvocations) (*profile, error) {
 	profile := &profile{
 		EntryBase: plugin.NewEntry(name),
 	}
@@ -58,7 +58,7 @@ func newProfile(ctx context.Context, name string) (*profile, error) {
 	}
 
 	profile.session = sess
-	profile.resourcesDir = []plugin.Entry{newResourcesDir(sess)}
+	profile.resourcesDir = []plugin.Entry{newResourcesDir(sess, execOpts, s3Opts, invocations)}
 
 	return profile, nil
 }
@@ -0,0 +1,53 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	rdsClient "github.com/aws/aws-sdk-go/service/rds"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// rdsDir represents the resources/rds directory
+type rdsDir struct {
+	plugin.EntryBase
+	client *rdsClient.RDS
+}
+
+func newRDSDir(ctx context.Context, session *session.Session) *rdsDir {
+	rdsDir := &rdsDir{
+		EntryBase: plugin.NewEntry("rds"),
+	}
+	rdsDir.client = rdsClient.New(session)
+	if _, err := plugin.List(ctx, rdsDir); err != nil {
+		rdsDir.MarkInaccessible(ctx, err)
+	}
+	return rdsDir
+}
+
+func (r *rdsDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(r, "rds").IsSingleton()
+}
+
+func (r *rdsDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&rdsInstance{}).Schema(),
+	}
+}
+
+// List lists the DB instances.
+func (r *rdsDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var entries []plugin.Entry
+	err := r.client.DescribeDBInstancesPagesWithContext(ctx, &rdsClient.DescribeDBInstancesInput{}, func(page *rdsClient.DescribeDBInstancesOutput, _ bool) bool {
+		for _, instance := range page.DBInstances {
+			entries = append(entries, newRDSInstance(instance, r.client))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Listing %v RDS instances", len(entries))
+	return entries, nil
+}
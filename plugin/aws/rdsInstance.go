@@ -0,0 +1,74 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	rdsClient "github.com/aws/aws-sdk-go/service/rds"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// rdsInstance represents an RDS DB instance
+type rdsInstance struct {
+	plugin.EntryBase
+	id     string
+	client *rdsClient.RDS
+}
+
+func newRDSInstance(instance *rdsClient.DBInstance, client *rdsClient.RDS) *rdsInstance {
+	id := awsSDK.StringValue(instance.DBInstanceIdentifier)
+	rdsInstance := &rdsInstance{
+		EntryBase: plugin.NewEntry(id),
+	}
+	rdsInstance.id = id
+	rdsInstance.client = client
+
+	crtime := awsSDK.TimeValue(instance.InstanceCreateTime)
+	rdsInstance.
+		// The instance's status changes as it's started and stopped.
+		SetTTLOf(plugin.ListOp, 30*time.Second).
+		SetPartialMetadata(instance).
+		Attributes().
+		SetCrtime(crtime).
+		SetMtime(crtime)
+	return rdsInstance
+}
+
+func (inst *rdsInstance) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(inst, "instance").
+		SetDescription(rdsInstanceDescription).
+		SetPartialMetadataSchema(rdsClient.DBInstance{}).
+		AddSignal("start", "Starts the DB instance").
+		AddSignal("stop", "Stops the DB instance").
+		AddSignal("restart", "Reboots the DB instance")
+}
+
+func (inst *rdsInstance) Signal(ctx context.Context, signal string) error {
+	var err error
+	switch signal {
+	case "start":
+		_, err = inst.client.StartDBInstanceWithContext(ctx, &rdsClient.StartDBInstanceInput{
+			DBInstanceIdentifier: awsSDK.String(inst.id),
+		})
+	case "stop":
+		_, err = inst.client.StopDBInstanceWithContext(ctx, &rdsClient.StopDBInstanceInput{
+			DBInstanceIdentifier: awsSDK.String(inst.id),
+		})
+	case "restart":
+		_, err = inst.client.RebootDBInstanceWithContext(ctx, &rdsClient.RebootDBInstanceInput{
+			DBInstanceIdentifier: awsSDK.String(inst.id),
+		})
+	default:
+		err = fmt.Errorf("unknown signal %v", signal)
+	}
+	return err
+}
+
+const rdsInstanceDescription = `
+This is an RDS DB instance. It can be started, stopped and rebooted with the
+start, stop and restart signals. Note that AWS automatically starts instances
+that have been stopped for seven days.
+`
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"context"
+	"sync"
 
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/puppetlabs/wash/plugin"
@@ -10,15 +11,22 @@ import (
 // resourcesDir represents the <profile>/resources directory
 type resourcesDir struct {
 	plugin.EntryBase
-	session *session.Session
+	session  *session.Session
+	execOpts ec2ExecOptions
+	s3Opts   s3Options
+	// invocations holds the responses of Lambda functions invoked through Wash.
+	invocations *lambdaInvocations
 }
 
-func newResourcesDir(session *session.Session) *resourcesDir {
+func newResourcesDir(session *session.Session, execOpts ec2ExecOptions, s3Opts s3Options, invocations *lambdaInvocations) *resourcesDir {
 	resourcesDir := &resourcesDir{
 		EntryBase: plugin.NewEntry("resources"),
 	}
 	resourcesDir.DisableDefaultCaching()
 	resourcesDir.session = session
+	resourcesDir.execOpts = execOpts
+	resourcesDir.s3Opts = s3Opts
+	resourcesDir.invocations = invocations
 	return resourcesDir
 }
 
@@ -30,13 +38,34 @@ func (r *resourcesDir) ChildSchemas() []*plugin.EntrySchema {
 	return []*plugin.EntrySchema{
 		(&s3Dir{}).Schema(),
 		(&ec2Dir{}).Schema(),
+		(&lambdaDir{}).Schema(),
+		(&ecsDir{}).Schema(),
+		(&rdsDir{}).Schema(),
+		(&cloudwatchLogsDir{}).Schema(),
 	}
 }
 
-// List lists the available AWS resources
+// List lists the available AWS resources. Most directories list their resources when
+// they're created to check that they're accessible, so they're created concurrently.
 func (r *resourcesDir) List(ctx context.Context) ([]plugin.Entry, error) {
-	return []plugin.Entry{
-		newS3Dir(ctx, r.session),
-		newEC2Dir(r.session),
-	}, nil
+	constructors := []func() plugin.Entry{
+		func() plugin.Entry { return newS3Dir(ctx, r.session, r.s3Opts) },
+		func() plugin.Entry { return newEC2Dir(r.session, r.execOpts) },
+		func() plugin.Entry { return newLambdaDir(ctx, r.session, r.invocations) },
+		func() plugin.Entry { return newECSDir(ctx, r.session) },
+		func() plugin.Entry { return newRDSDir(ctx, r.session) },
+		func() plugin.Entry { return newCloudwatchLogsDir(ctx, r.session) },
+	}
+
+	entries := make([]plugin.Entry, len(constructors))
+	var wg sync.WaitGroup
+	for i, constructor := range constructors {
+		wg.Add(1)
+		go func(i int, constructor func() plugin.Entry) {
+			defer wg.Done()
+			entries[i] = constructor()
+		}(i, constructor)
+	}
+	wg.Wait()
+	return entries, nil
 }
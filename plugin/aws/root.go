@@ -20,7 +20,82 @@ import (
 // Root of the AWS plugin
 type Root struct {
 	plugin.EntryBase
-	profs map[string]struct{}
+	profs    map[string]struct{}
+	execOpts map[string]ec2ExecOptions
+	s3Opts   s3Options
+	// lambdaInvocations outlives the profiles, which are recreated whenever the root's
+	// re-listed, so that invocation responses are still available afterwards.
+	lambdaInvocations *lambdaInvocations
+}
+
+// ec2ExecOptions configures how Wash executes commands on a profile's EC2 instances.
+type ec2ExecOptions struct {
+	// method is one of "ssh", "ssm", "winrm" or "auto". See ec2Instance#Exec for details.
+	method string
+	winrm  winrmOptions
+}
+
+func (opts ec2ExecOptions) validate(profile string) error {
+	switch opts.method {
+	case "auto", "ssh", "ssm", "winrm":
+		return nil
+	default:
+		return fmt.Errorf("aws.exec.%v config must be one of auto, ssh, ssm or winrm, not %v", profile, opts.method)
+	}
+}
+
+// winrmOptions configures how Wash connects to a profile's Windows instances over WinRM.
+// If password is empty, then Wash decrypts the instance's Administrator password with the
+// private key of the instance's key pair.
+type winrmOptions struct {
+	user     string
+	password string
+	port     int
+	https    bool
+	insecure bool
+	basic    bool
+}
+
+func parseWinRMOptions(profile string, cfgI interface{}) (winrmOptions, error) {
+	opts := winrmOptions{}
+	cfg, ok := cfgI.(map[string]interface{})
+	if !ok {
+		return opts, fmt.Errorf("aws.winrm.%v config must be a map, not %v", profile, cfgI)
+	}
+	for key, value := range cfg {
+		var ok bool
+		switch key {
+		case "user":
+			opts.user, ok = value.(string)
+		case "password":
+			opts.password, ok = value.(string)
+		case "https":
+			opts.https, ok = value.(bool)
+		case "insecure":
+			opts.insecure, ok = value.(bool)
+		case "port":
+			opts.port, ok = value.(int)
+			ok = ok && opts.port > 0
+		case "auth":
+			var auth string
+			auth, ok = value.(string)
+			ok = ok && (auth == "ntlm" || auth == "basic")
+			opts.basic = auth == "basic"
+		default:
+			return opts, fmt.Errorf("aws.winrm.%v config contains unknown key %v", profile, key)
+		}
+		if !ok {
+			return opts, fmt.Errorf("aws.winrm.%v.%v config is invalid: %v", profile, key, value)
+		}
+	}
+	return opts, nil
+}
+
+// s3Options configures how Wash presents S3 buckets.
+type s3Options struct {
+	// showDeleted includes keys whose latest version is a delete marker in a versioned
+	// bucket's .versions directory.
+	showDeleted bool
 }
 
 func awsCredentialsFile() (string, error) {
@@ -64,6 +139,7 @@ func exists(path string) (bool, error) {
 func (r *Root) Init(cfg map[string]interface{}) error {
 	r.EntryBase = plugin.NewEntry("aws")
 	r.SetTTLOf(plugin.ListOp, 1*time.Minute)
+	r.lambdaInvocations = newLambdaInvocations()
 
 	if profsI, ok := cfg["profiles"]; ok {
 		profs, ok := profsI.([]interface{})
@@ -81,6 +157,58 @@ func (r *Root) Init(cfg map[string]interface{}) error {
 		}
 	}
 
+	r.execOpts = make(map[string]ec2ExecOptions)
+	if execI, ok := cfg["exec"]; ok {
+		execMap, ok := execI.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("aws.exec config must be a map of profile names to exec methods, not %v", execI)
+		}
+		for prof, methodI := range execMap {
+			method, ok := methodI.(string)
+			if !ok {
+				return fmt.Errorf("aws.exec.%v config must be a string, not %v", prof, methodI)
+			}
+			opts := ec2ExecOptions{method: method}
+			if err := opts.validate(prof); err != nil {
+				return err
+			}
+			r.execOpts[prof] = opts
+		}
+	}
+
+	if winrmI, ok := cfg["winrm"]; ok {
+		winrmMap, ok := winrmI.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("aws.winrm config must be a map of profile names to WinRM options, not %v", winrmI)
+		}
+		for prof, optsI := range winrmMap {
+			winrmOpts, err := parseWinRMOptions(prof, optsI)
+			if err != nil {
+				return err
+			}
+			opts, ok := r.execOpts[prof]
+			if !ok {
+				opts.method = "auto"
+			}
+			opts.winrm = winrmOpts
+			r.execOpts[prof] = opts
+		}
+	}
+
+	if s3I, ok := cfg["s3"]; ok {
+		s3Map, ok := s3I.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("aws.s3 config must be a map, not %v", s3I)
+		}
+		if showDeletedI, ok := s3Map["show_deleted"]; ok {
+			showDeleted, ok := showDeletedI.(bool)
+			if !ok {
+				return fmt.Errorf("aws.s3.show_deleted config must be a bool, not %v", showDeletedI)
+			}
+			r.s3Opts.showDeleted = showDeleted
+		}
+	}
+
 	// Force authorizing profiles on startup
 	_, err := r.List(context.Background())
 	return err
@@ -171,7 +299,11 @@ func (r *Root) List(ctx context.Context) ([]plugin.Entry, error) {
 			continue
 		}
 
-		profile, err := newProfile(ctx, name)
+		execOpts, ok := r.execOpts[name]
+		if !ok {
+			execOpts = ec2ExecOptions{method: "auto"}
+		}
+		profile, err := newProfile(ctx, name, execOpts, r.s3Opts, r.lambdaInvocations)
 		if err != nil {
 			activity.Warnf(ctx, err.Error())
 			continue
@@ -197,7 +329,45 @@ aws:
 
 to Wash’s config file.
 
-The AWS plugin currently supports EC2 and S3. IAM roles are supported when configured
+By default, Wash executes commands on EC2 instances through SSM if they're
+managed by SSM, through WinRM if they're Windows instances, and through SSH
+otherwise. You can pick a method per profile by adding
+
+aws:
+  exec:
+    profile_1: ssm
+    profile_2: ssh
+    profile_3: winrm
+
+to Wash’s config file.
+
+WinRM connects over HTTP with NTLM authentication as the Administrator, whose
+password is decrypted with the private key of the instance's key pair (expected
+at ~/.ssh/<key_name>.pem). You can configure the connection per profile with
+
+aws:
+  winrm:
+    profile_1:
+      user: Administrator
+      password: <password>
+      https: true
+      insecure: true
+      port: 5986
+      auth: basic
+
+where every key is optional. auth can be ntlm or basic.
+
+Versioned S3 buckets include a .versions directory with every version of their
+objects. Objects whose latest version is a delete marker are hidden from it
+unless you add
+
+aws:
+  s3:
+    show_deleted: true
+
+to Wash’s config file.
+
+The AWS plugin currently supports EC2, S3, Lambda, ECS, RDS and CloudWatch Logs. IAM roles are supported when configured
 as described here. Note that currently region will also need to be specified with the
 profile.
 
@@ -156,6 +156,21 @@ func deleteObjects(ctx context.Context, client *s3Client.S3, bucket string, pref
 	return s3manager.NewBatchDeleteWithClient(client).Delete(ctx, iterator)
 }
 
+// deleteKeys deletes the given objects from the bucket.
+func deleteKeys(ctx context.Context, client *s3Client.S3, bucket string, keys []string) error {
+	objects := make([]s3manager.BatchDeleteObject, len(keys))
+	for i, key := range keys {
+		objects[i] = s3manager.BatchDeleteObject{
+			Object: &s3Client.DeleteObjectInput{
+				Bucket: awsSDK.String(bucket),
+				Key:    awsSDK.String(key),
+			},
+		}
+	}
+	iterator := &s3manager.DeleteObjectsIterator{Objects: objects}
+	return s3manager.NewBatchDeleteWithClient(client).Delete(ctx, iterator)
+}
+
 // s3Bucket represents an S3 bucket.
 type s3Bucket struct {
 	plugin.EntryBase
@@ -163,9 +178,10 @@ type s3Bucket struct {
 	client  *s3Client.S3
 	cwcli   *cloudwatch.CloudWatch
 	session *session.Session
+	opts    s3Options
 }
 
-func newS3Bucket(name string, crtime time.Time, session *session.Session) *s3Bucket {
+func newS3Bucket(name string, crtime time.Time, session *session.Session, opts s3Options) *s3Bucket {
 	bucket := &s3Bucket{
 		EntryBase: plugin.NewEntry(name),
 	}
@@ -173,6 +189,7 @@ func newS3Bucket(name string, crtime time.Time, session *session.Session) *s3Buc
 	bucket.client = s3Client.New(session)
 	bucket.cwcli = cloudwatch.New(session)
 	bucket.session = session
+	bucket.opts = opts
 	bucket.
 		Attributes().
 		SetCrtime(bucket.crtime).
@@ -191,14 +208,70 @@ func (b *s3Bucket) Schema() *plugin.EntrySchema {
 }
 
 func (b *s3Bucket) ChildSchemas() []*plugin.EntrySchema {
-	return (&s3ObjectPrefix{}).ChildSchemas()
+	return append(
+		(&s3ObjectPrefix{}).ChildSchemas(),
+		(&s3VersionsDir{}).Schema(),
+	)
 }
 
 func (b *s3Bucket) List(ctx context.Context) ([]plugin.Entry, error) {
 	if _, err := b.getRegion(ctx); err != nil {
 		return nil, err
 	}
-	return listObjects(ctx, b.client, b.Name(), "")
+	entries, err := listObjects(ctx, b.client, b.Name(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	versioned, err := b.isVersioned(ctx)
+	if err != nil {
+		activity.Record(ctx, "Unable to determine whether bucket %v is versioned: %v", b.Name(), err)
+		return entries, nil
+	}
+	if !versioned {
+		return entries, nil
+	}
+	for _, entry := range entries {
+		if plugin.Name(entry) == versionsDirName {
+			activity.Record(ctx, "Omitting versions of bucket %v because it contains a %v key", b.Name(), versionsDirName)
+			return entries, nil
+		}
+	}
+	return append(entries, newS3VersionsDir(versionsDirName, b.Name(), "", b.client, b.opts)), nil
+}
+
+// isVersioned returns whether versioning is (or was) enabled on the bucket. Suspended buckets
+// are included because they can still have versions from when versioning was enabled.
+func (b *s3Bucket) isVersioned(ctx context.Context) (bool, error) {
+	versioned, err := plugin.CachedOp(ctx, "Versioning", b, 1*time.Hour, func() (interface{}, error) {
+		resp, err := b.client.GetBucketVersioningWithContext(ctx, &s3Client.GetBucketVersioningInput{
+			Bucket: awsSDK.String(b.Name()),
+		})
+		if err != nil {
+			return false, err
+		}
+		return awsSDK.StringValue(resp.Status) != "", nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return versioned.(bool), nil
+}
+
+// Create uploads a new object to the top of the bucket.
+func (b *s3Bucket) Create(ctx context.Context, name string, content []byte) (plugin.Entry, error) {
+	if _, err := b.getRegion(ctx); err != nil {
+		return nil, err
+	}
+	return createObject(ctx, b.client, b.Name(), "", name, content)
+}
+
+// CreateDir creates a new prefix at the top of the bucket.
+func (b *s3Bucket) CreateDir(ctx context.Context, name string) (plugin.Parent, error) {
+	if _, err := b.getRegion(ctx); err != nil {
+		return nil, err
+	}
+	return createPrefix(ctx, b.client, b.Name(), "", name)
 }
 
 func (b *s3Bucket) Delete(ctx context.Context) (bool, error) {
@@ -321,4 +394,15 @@ For example, the objects 'foo/bar' and 'foo/baz' are represented as files with
 path 'foo/bar' and path 'foo/baz', where 'foo' is represented as a 'directory'.
 Thus, if you ls this bucket, then everything you'll see is either an S3 object
 prefix ('directory') or an S3 object ('file').
+
+Versioned buckets also include a .versions directory. See its docs for more
+details.
+
+You can create new objects in the bucket or any of its prefixes (e.g. with
+'cp' or by redirecting output to a new file). Large objects are uploaded in
+parts, so writing objects larger than 5 GB is supported. Note that copying
+between S3 paths through the filesystem still reads and writes the object's
+content locally because FUSE can't tell that a new file is a copy; Wash uses
+S3's server-side copy for operations where it knows the source, like renames.
+You can also create prefixes with 'mkdir'.
 `
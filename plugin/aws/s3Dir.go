@@ -18,13 +18,15 @@ type s3Dir struct {
 	plugin.EntryBase
 	session *session.Session
 	client  *s3Client.S3
+	opts    s3Options
 }
 
-func newS3Dir(ctx context.Context, session *session.Session) *s3Dir {
+func newS3Dir(ctx context.Context, session *session.Session, opts s3Options) *s3Dir {
 	s3Dir := &s3Dir{
 		EntryBase: plugin.NewEntry("s3"),
 	}
 	s3Dir.session = session
+	s3Dir.opts = opts
 
 	// All S3 buckets can be listed from any region. Normalize the configured region so we can still
 	// list buckets if region is unspecified.
@@ -62,6 +64,7 @@ func (s *s3Dir) List(ctx context.Context) ([]plugin.Entry, error) {
 			awsSDK.StringValue(bucket.Name),
 			awsSDK.TimeValue(bucket.CreationDate),
 			s.session,
+			s.opts,
 		)
 	}
 
@@ -3,7 +3,10 @@ package aws
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"net/url"
 	"strconv"
 
 	"github.com/puppetlabs/wash/activity"
@@ -12,8 +15,17 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	awsSDK "github.com/aws/aws-sdk-go/aws"
 	s3Client "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 )
 
+// maxCopyObjectSize is the largest object that can be copied with a single CopyObject
+// request. Larger objects are copied in parts.
+const maxCopyObjectSize = 5 * 1024 * 1024 * 1024
+
+// copyPartSize is the size of each part when copying large objects. It's large enough
+// that the largest possible S3 object (5 TB) fits within the 10000 part limit.
+const copyPartSize = 512 * 1024 * 1024
+
 // s3Object represents an S3 object.
 type s3Object struct {
 	plugin.EntryBase
@@ -73,6 +85,85 @@ func (o *s3Object) Metadata(ctx context.Context) (plugin.JSONObject, error) {
 }
 
 func (o *s3Object) Read(ctx context.Context, size int64, offset int64) ([]byte, error) {
+	return readObject(ctx, o.client, o.bucket, o.key, "", size, offset)
+}
+
+func (o *s3Object) Write(ctx context.Context, p []byte) error {
+	return uploadObject(ctx, o.client, o.bucket, o.key, bytes.NewReader(p), int64(len(p)))
+}
+
+// WriteStream uploads the new content as it's written, so that it's only buffered a part at a
+// time.
+func (o *s3Object) WriteStream(ctx context.Context, size int64) (io.WriteCloser, error) {
+	r, w := io.Pipe()
+	uploaded := make(chan error, 1)
+	go func() {
+		err := uploadObject(ctx, o.client, o.bucket, o.key, r, size)
+		// Stop any writes that are waiting for the upload to read them.
+		r.CloseWithError(err)
+		uploaded <- err
+	}()
+	return &uploadWriter{PipeWriter: w, uploaded: uploaded}, nil
+}
+
+// uploadWriter writes the content of an upload. Closing it waits for the upload to finish.
+type uploadWriter struct {
+	*io.PipeWriter
+	uploaded <-chan error
+}
+
+func (w *uploadWriter) Close() error {
+	if err := w.PipeWriter.Close(); err != nil {
+		return err
+	}
+	return <-w.uploaded
+}
+
+func (o *s3Object) Delete(ctx context.Context) (bool, error) {
+	_, err := o.client.DeleteObjectWithContext(ctx, &s3Client.DeleteObjectInput{
+		Bucket: aws.String(o.bucket),
+		Key:    aws.String(o.key),
+	})
+	return true, err
+}
+
+// Rename moves the object with a server-side copy followed by a delete, because S3 can't
+// rename objects. The object can be moved to any bucket or prefix.
+func (o *s3Object) Rename(ctx context.Context, newParent plugin.Parent, newName string) (plugin.Entry, error) {
+	client, bucket, prefix, err := renameDestination(ctx, newParent)
+	if err != nil {
+		return nil, err
+	}
+
+	key := prefix + newName
+	if err := copyObject(ctx, client, o.bucket, o.key, bucket, key); err != nil {
+		return nil, err
+	}
+	if _, err := o.Delete(ctx); err != nil {
+		return nil, fmt.Errorf("copied %v to %v/%v, but could not delete it: %w", o.key, bucket, key, err)
+	}
+	return headObject(ctx, client, bucket, key, newName)
+}
+
+// renameDestination is a helper that returns the bucket and prefix that entries are moved to
+// when they're renamed to a child of newParent.
+func renameDestination(ctx context.Context, newParent plugin.Parent) (*s3Client.S3, string, string, error) {
+	switch p := newParent.(type) {
+	case *s3Bucket:
+		if _, err := p.getRegion(ctx); err != nil {
+			return nil, "", "", err
+		}
+		return p.client, p.Name(), "", nil
+	case *s3ObjectPrefix:
+		return p.client, p.bucket, p.prefix, nil
+	default:
+		return nil, "", "", plugin.ErrCrossParentRename
+	}
+}
+
+// readObject is a helper that reads part of an object. If versionID is empty, then it reads
+// the object's latest version.
+func readObject(ctx context.Context, client *s3Client.S3, bucket string, key string, versionID string, size int64, offset int64) ([]byte, error) {
 	// Because bytes request is inclusive, short-circuit requests for 0 bytes.
 	if size <= 0 || offset < 0 {
 		return []byte{}, nil
@@ -80,12 +171,15 @@ func (o *s3Object) Read(ctx context.Context, size int64, offset int64) ([]byte,
 
 	// Bytes range is inclusive, so for N bytes get byte range 0-(N-1).
 	request := &s3Client.GetObjectInput{
-		Bucket: awsSDK.String(o.bucket),
-		Key:    awsSDK.String(o.key),
+		Bucket: awsSDK.String(bucket),
+		Key:    awsSDK.String(key),
 		Range:  awsSDK.String("bytes=" + strconv.FormatInt(offset, 10) + "-" + strconv.FormatInt(offset+size-1, 10)),
 	}
+	if versionID != "" {
+		request.VersionId = awsSDK.String(versionID)
+	}
 
-	resp, err := o.client.GetObjectWithContext(ctx, request)
+	resp, err := client.GetObjectWithContext(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -99,31 +193,133 @@ func (o *s3Object) Read(ctx context.Context, size int64, offset int64) ([]byte,
 	return ioutil.ReadAll(resp.Body)
 }
 
-func (o *s3Object) Write(ctx context.Context, p []byte) error {
-	request := &s3Client.PutObjectInput{
-		Bucket: awsSDK.String(o.bucket),
-		Key:    awsSDK.String(o.key),
-		Body:   bytes.NewReader(p),
-	}
-
-	resp, err := o.client.PutObjectWithContext(ctx, request)
+// uploadObject is a helper that uploads size bytes of content to the specified key. The uploader
+// switches to a multipart upload for large content, which is required for objects over 5 GB.
+func uploadObject(ctx context.Context, client *s3Client.S3, bucket string, key string, content io.Reader, size int64) error {
+	uploader := s3manager.NewUploaderWithClient(client, func(u *s3manager.Uploader) {
+		// The uploader can't tell the size of content that isn't seekable, so make the parts large
+		// enough that it fits in the most parts that an upload can have.
+		if partSize := size/s3manager.MaxUploadParts + 1; partSize > u.PartSize {
+			u.PartSize = partSize
+		}
+	})
+	resp, err := uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: awsSDK.String(bucket),
+		Key:    awsSDK.String(key),
+		Body:   content,
+	})
 	if err != nil {
 		return err
 	}
 
-	activity.Record(ctx, "S3 object write response: %+v", *resp)
+	activity.Record(ctx, "S3 object upload response: %+v", *resp)
 	return nil
 }
 
-func (o *s3Object) Delete(ctx context.Context) (bool, error) {
-	_, err := o.client.DeleteObjectWithContext(ctx, &s3Client.DeleteObjectInput{
-		Bucket: aws.String(o.bucket),
-		Key:    aws.String(o.key),
+// createObject is a helper that uploads a new object, then returns it as an entry.
+func createObject(ctx context.Context, client *s3Client.S3, bucket string, prefix string, name string, content []byte) (*s3Object, error) {
+	key := prefix + name
+	if err := uploadObject(ctx, client, bucket, key, bytes.NewReader(content), int64(len(content))); err != nil {
+		return nil, err
+	}
+	return headObject(ctx, client, bucket, key, name)
+}
+
+// headObject is a helper that returns the object with the specified key as an entry.
+func headObject(ctx context.Context, client *s3Client.S3, bucket string, key string, name string) (*s3Object, error) {
+	head, err := client.HeadObjectWithContext(ctx, &s3Client.HeadObjectInput{
+		Bucket: awsSDK.String(bucket),
+		Key:    awsSDK.String(key),
 	})
-	return true, err
+	if err != nil {
+		return nil, err
+	}
+	obj := &s3Client.Object{
+		ETag:         head.ETag,
+		Key:          awsSDK.String(key),
+		LastModified: head.LastModified,
+		Size:         head.ContentLength,
+		StorageClass: head.StorageClass,
+	}
+	return newS3Object(obj, name, bucket, key, client), nil
+}
+
+// copyObject is a helper that copies an object within S3, so that its content never leaves
+// AWS. Objects larger than 5 GB are copied with a multipart upload.
+func copyObject(ctx context.Context, client *s3Client.S3, srcBucket string, srcKey string, dstBucket string, dstKey string) error {
+	head, err := client.HeadObjectWithContext(ctx, &s3Client.HeadObjectInput{
+		Bucket: awsSDK.String(srcBucket),
+		Key:    awsSDK.String(srcKey),
+	})
+	if err != nil {
+		return err
+	}
+
+	copySource := url.PathEscape(srcBucket + "/" + srcKey)
+	size := awsSDK.Int64Value(head.ContentLength)
+	if size <= maxCopyObjectSize {
+		_, err := client.CopyObjectWithContext(ctx, &s3Client.CopyObjectInput{
+			Bucket:     awsSDK.String(dstBucket),
+			Key:        awsSDK.String(dstKey),
+			CopySource: awsSDK.String(copySource),
+		})
+		activity.Record(ctx, "Copied S3 object %v to %v/%v: %v", copySource, dstBucket, dstKey, err)
+		return err
+	}
+
+	upload, err := client.CreateMultipartUploadWithContext(ctx, &s3Client.CreateMultipartUploadInput{
+		Bucket:      awsSDK.String(dstBucket),
+		Key:         awsSDK.String(dstKey),
+		ContentType: head.ContentType,
+		Metadata:    head.Metadata,
+	})
+	if err != nil {
+		return err
+	}
+
+	var parts []*s3Client.CompletedPart
+	for offset, partNumber := int64(0), int64(1); offset < size; offset, partNumber = offset+copyPartSize, partNumber+1 {
+		end := offset + copyPartSize - 1
+		if end >= size {
+			end = size - 1
+		}
+		part, err := client.UploadPartCopyWithContext(ctx, &s3Client.UploadPartCopyInput{
+			Bucket:          awsSDK.String(dstBucket),
+			Key:             awsSDK.String(dstKey),
+			UploadId:        upload.UploadId,
+			PartNumber:      awsSDK.Int64(partNumber),
+			CopySource:      awsSDK.String(copySource),
+			CopySourceRange: awsSDK.String(fmt.Sprintf("bytes=%v-%v", offset, end)),
+		})
+		if err != nil {
+			_, abortErr := client.AbortMultipartUploadWithContext(context.Background(), &s3Client.AbortMultipartUploadInput{
+				Bucket:   awsSDK.String(dstBucket),
+				Key:      awsSDK.String(dstKey),
+				UploadId: upload.UploadId,
+			})
+			activity.Record(ctx, "Aborted copy of S3 object %v to %v/%v: %v", copySource, dstBucket, dstKey, abortErr)
+			return err
+		}
+		parts = append(parts, &s3Client.CompletedPart{
+			ETag:       part.CopyPartResult.ETag,
+			PartNumber: awsSDK.Int64(partNumber),
+		})
+	}
+
+	_, err = client.CompleteMultipartUploadWithContext(ctx, &s3Client.CompleteMultipartUploadInput{
+		Bucket:          awsSDK.String(dstBucket),
+		Key:             awsSDK.String(dstKey),
+		UploadId:        upload.UploadId,
+		MultipartUpload: &s3Client.CompletedMultipartUpload{Parts: parts},
+	})
+	activity.Record(ctx, "Copied S3 object %v to %v/%v in %v parts: %v", copySource, dstBucket, dstKey, len(parts), err)
+	return err
 }
 
 const s3ObjectDescription = `
 This is an S3 object. See the bucket's docs for more details on
 why we have this kind of entry.
+
+S3 can't rename objects, so renaming or moving an object (e.g. with 'mv')
+copies it to the new key, then deletes the original.
 `
@@ -1,10 +1,14 @@
 package aws
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/puppetlabs/wash/plugin"
 
+	awsSDK "github.com/aws/aws-sdk-go/aws"
 	s3Client "github.com/aws/aws-sdk-go/service/s3"
 )
 
@@ -46,12 +50,79 @@ func (d *s3ObjectPrefix) List(ctx context.Context) ([]plugin.Entry, error) {
 	return listObjects(ctx, d.client, d.bucket, d.prefix)
 }
 
+// Create uploads a new object under the prefix.
+func (d *s3ObjectPrefix) Create(ctx context.Context, name string, content []byte) (plugin.Entry, error) {
+	return createObject(ctx, d.client, d.bucket, d.prefix, name, content)
+}
+
+// CreateDir creates a new prefix under the prefix.
+func (d *s3ObjectPrefix) CreateDir(ctx context.Context, name string) (plugin.Parent, error) {
+	return createPrefix(ctx, d.client, d.bucket, d.prefix, name)
+}
+
 func (d *s3ObjectPrefix) Delete(ctx context.Context) (bool, error) {
 	err := deleteObjects(ctx, d.client, d.bucket, d.prefix)
 	return true, err
 }
 
+// Rename moves every object under the prefix to the new prefix with server-side copies,
+// then deletes the originals.
+func (d *s3ObjectPrefix) Rename(ctx context.Context, newParent plugin.Parent, newName string) (plugin.Entry, error) {
+	client, bucket, prefix, err := renameDestination(ctx, newParent)
+	if err != nil {
+		return nil, err
+	}
+
+	newPrefix := prefix + newName + "/"
+	if bucket == d.bucket && strings.HasPrefix(newPrefix, d.prefix) {
+		return nil, fmt.Errorf("cannot move %v into itself", d.prefix)
+	}
+	// Only the copied keys are deleted so that objects added to d.prefix during the
+	// rename aren't lost.
+	var copied []string
+	var copyErr error
+	err = d.client.ListObjectsV2PagesWithContext(ctx, &s3Client.ListObjectsV2Input{
+		Bucket: awsSDK.String(d.bucket),
+		Prefix: awsSDK.String(d.prefix),
+	}, func(page *s3Client.ListObjectsV2Output, lastPage bool) bool {
+		for _, o := range page.Contents {
+			key := awsSDK.StringValue(o.Key)
+			copyErr = copyObject(ctx, client, d.bucket, key, bucket, newPrefix+strings.TrimPrefix(key, d.prefix))
+			if copyErr != nil {
+				return false
+			}
+			copied = append(copied, key)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	} else if copyErr != nil {
+		return nil, copyErr
+	}
+	if err := deleteKeys(ctx, d.client, d.bucket, copied); err != nil {
+		return nil, fmt.Errorf("copied %v to %v/%v, but could not delete it: %w", d.prefix, bucket, newPrefix, err)
+	}
+	return newS3ObjectPrefix(newName, bucket, newPrefix, client), nil
+}
+
+// createPrefix is a helper that creates an empty prefix. S3 doesn't have directories, so
+// like the AWS console, we upload an empty object whose key is the new prefix. It's hidden
+// when listing the prefix.
+func createPrefix(ctx context.Context, client *s3Client.S3, bucket string, prefix string, name string) (*s3ObjectPrefix, error) {
+	newPrefix := prefix + name + "/"
+	if err := uploadObject(ctx, client, bucket, newPrefix, bytes.NewReader(nil), 0); err != nil {
+		return nil, err
+	}
+	return newS3ObjectPrefix(name, bucket, newPrefix, client), nil
+}
+
 const s3ObjectPrefixDescription = `
 This represents a common prefix shared by multiple S3 objects. See the
 bucket's docs for more details on why we have this kind of entry.
+
+New prefixes (e.g. from 'mkdir') are created by uploading an empty object
+whose key ends in a '/', which is what the AWS console does. Renaming a
+prefix (e.g. with 'mv') copies each of its objects to the new prefix, then
+deletes the originals.
 `
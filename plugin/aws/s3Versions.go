@@ -0,0 +1,302 @@
+package aws
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	s3Client "github.com/aws/aws-sdk-go/service/s3"
+)
+
+// versionsDirName is the name of a versioned bucket's versions directory. The leading dot
+// hides it from ls and makes it unlikely to conflict with a key.
+const versionsDirName = ".versions"
+
+// s3VersionsDir mirrors the bucket's hierarchy of prefixes, but lists every key that has
+// versions (including deleted keys) instead of only the current objects.
+type s3VersionsDir struct {
+	plugin.EntryBase
+	bucket string
+	prefix string
+	client *s3Client.S3
+	opts   s3Options
+}
+
+func newS3VersionsDir(name string, bucket string, prefix string, client *s3Client.S3, opts s3Options) *s3VersionsDir {
+	versionsDir := &s3VersionsDir{
+		EntryBase: plugin.NewEntry(name),
+	}
+	versionsDir.bucket = bucket
+	versionsDir.prefix = prefix
+	versionsDir.client = client
+	versionsDir.opts = opts
+	return versionsDir
+}
+
+func (d *s3VersionsDir) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(d, "versions").
+		SetDescription(s3VersionsDirDescription)
+}
+
+func (d *s3VersionsDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&s3VersionsDir{}).Schema(),
+		(&s3ObjectVersions{}).Schema(),
+	}
+}
+
+type s3ObjectVersionsMetadata struct {
+	Key          string
+	Deleted      bool
+	LastModified time.Time
+}
+
+// List lists the prefixes and keys directly under the current prefix. It uses the same
+// delimiter semantics as listObjects.
+func (d *s3VersionsDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	request := &s3Client.ListObjectVersionsInput{
+		Bucket:    awsSDK.String(d.bucket),
+		Prefix:    awsSDK.String(d.prefix),
+		Delimiter: awsSDK.String("/"),
+	}
+
+	var prefixes []string
+	keys := make(map[string]*s3ObjectVersionsMetadata)
+	latest := func(key string) *s3ObjectVersionsMetadata {
+		if _, ok := keys[key]; !ok {
+			keys[key] = &s3ObjectVersionsMetadata{Key: key}
+		}
+		return keys[key]
+	}
+	err := d.client.ListObjectVersionsPagesWithContext(ctx, request, func(page *s3Client.ListObjectVersionsOutput, lastPage bool) bool {
+		for _, p := range page.CommonPrefixes {
+			prefixes = append(prefixes, awsSDK.StringValue(p.Prefix))
+		}
+		for _, v := range page.Versions {
+			if awsSDK.BoolValue(v.IsLatest) {
+				latest(awsSDK.StringValue(v.Key)).LastModified = awsSDK.TimeValue(v.LastModified)
+			}
+		}
+		for _, m := range page.DeleteMarkers {
+			if awsSDK.BoolValue(m.IsLatest) {
+				meta := latest(awsSDK.StringValue(m.Key))
+				meta.Deleted = true
+				meta.LastModified = awsSDK.TimeValue(m.LastModified)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	activity.Record(
+		ctx,
+		"(Bucket %v, Prefix %v): Retrieved %v prefixes and %v versioned keys",
+		d.bucket,
+		d.prefix,
+		len(prefixes),
+		len(keys),
+	)
+
+	entries := make([]plugin.Entry, 0, len(prefixes)+len(keys))
+	for _, commonPrefix := range prefixes {
+		name := strings.TrimPrefix(commonPrefix, d.prefix)
+		if name != "/" {
+			name = strings.TrimSuffix(name, "/")
+		}
+		entries = append(entries, newS3VersionsDir(name, d.bucket, commonPrefix, d.client, d.opts))
+	}
+	for key, meta := range keys {
+		name := strings.TrimPrefix(key, d.prefix)
+		if name == "" {
+			continue
+		}
+		if meta.Deleted && !d.opts.showDeleted {
+			continue
+		}
+		entries = append(entries, newS3ObjectVersions(name, d.bucket, meta, d.client))
+	}
+	return entries, nil
+}
+
+// s3ObjectVersions represents all the versions of a key.
+type s3ObjectVersions struct {
+	plugin.EntryBase
+	bucket string
+	key    string
+	client *s3Client.S3
+}
+
+func newS3ObjectVersions(name string, bucket string, meta *s3ObjectVersionsMetadata, client *s3Client.S3) *s3ObjectVersions {
+	versions := &s3ObjectVersions{
+		EntryBase: plugin.NewEntry(name),
+	}
+	versions.bucket = bucket
+	versions.key = meta.Key
+	versions.client = client
+	versions.
+		SetPartialMetadata(meta).
+		Attributes().
+		SetMtime(meta.LastModified)
+	return versions
+}
+
+func (v *s3ObjectVersions) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(v, "object_versions").
+		SetDescription(s3ObjectVersionsDescription).
+		SetPartialMetadataSchema(s3ObjectVersionsMetadata{})
+}
+
+func (v *s3ObjectVersions) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&s3ObjectVersion{}).Schema(),
+		(&s3DeleteMarker{}).Schema(),
+	}
+}
+
+func (v *s3ObjectVersions) List(ctx context.Context) ([]plugin.Entry, error) {
+	request := &s3Client.ListObjectVersionsInput{
+		Bucket: awsSDK.String(v.bucket),
+		Prefix: awsSDK.String(v.key),
+	}
+
+	var entries []plugin.Entry
+	err := v.client.ListObjectVersionsPagesWithContext(ctx, request, func(page *s3Client.ListObjectVersionsOutput, lastPage bool) bool {
+		// Prefix also matches longer keys, so only keep the key's own versions. Versions are
+		// listed in key order and the key sorts before every longer key with it as a prefix,
+		// so we're done once we see any other key.
+		passedKey := false
+		for _, ver := range page.Versions {
+			if awsSDK.StringValue(ver.Key) == v.key {
+				entries = append(entries, newS3ObjectVersion(ver, v.bucket, v.client))
+			} else {
+				passedKey = true
+			}
+		}
+		for _, marker := range page.DeleteMarkers {
+			if awsSDK.StringValue(marker.Key) == v.key {
+				entries = append(entries, newS3DeleteMarker(marker))
+			} else {
+				passedKey = true
+			}
+		}
+		return !passedKey
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// s3ObjectVersion represents a single version of an S3 object.
+type s3ObjectVersion struct {
+	plugin.EntryBase
+	bucket    string
+	key       string
+	versionID string
+	client    *s3Client.S3
+}
+
+func newS3ObjectVersion(v *s3Client.ObjectVersion, bucket string, client *s3Client.S3) *s3ObjectVersion {
+	version := &s3ObjectVersion{
+		EntryBase: plugin.NewEntry(awsSDK.StringValue(v.VersionId)),
+	}
+	version.bucket = bucket
+	version.key = awsSDK.StringValue(v.Key)
+	version.versionID = awsSDK.StringValue(v.VersionId)
+	version.client = client
+
+	// Versions are immutable, so their mtime is also their creation time.
+	mtime := awsSDK.TimeValue(v.LastModified)
+	version.
+		SetPartialMetadata(v).
+		Attributes().
+		SetCrtime(mtime).
+		SetMtime(mtime).
+		SetCtime(mtime).
+		SetAtime(mtime).
+		SetSize(uint64(awsSDK.Int64Value(v.Size)))
+	return version
+}
+
+func (v *s3ObjectVersion) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(v, "version").
+		SetDescription(s3ObjectVersionDescription).
+		SetPartialMetadataSchema(s3Client.ObjectVersion{})
+}
+
+func (v *s3ObjectVersion) Read(ctx context.Context, size int64, offset int64) ([]byte, error) {
+	return readObject(ctx, v.client, v.bucket, v.key, v.versionID, size, offset)
+}
+
+// s3DeleteMarker represents a delete marker, which is the version that's created when
+// an object's deleted from a versioned bucket.
+type s3DeleteMarker struct {
+	plugin.EntryBase
+}
+
+func newS3DeleteMarker(m *s3Client.DeleteMarkerEntry) *s3DeleteMarker {
+	marker := &s3DeleteMarker{
+		EntryBase: plugin.NewEntry(awsSDK.StringValue(m.VersionId)),
+	}
+
+	mtime := awsSDK.TimeValue(m.LastModified)
+	marker.
+		SetPartialMetadata(m).
+		Attributes().
+		SetCrtime(mtime).
+		SetMtime(mtime).
+		SetCtime(mtime).
+		SetAtime(mtime).
+		SetSize(0)
+	return marker
+}
+
+func (m *s3DeleteMarker) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(m, "delete_marker").
+		SetDescription(s3DeleteMarkerDescription).
+		SetPartialMetadataSchema(s3Client.DeleteMarkerEntry{})
+}
+
+const s3VersionsDirDescription = `
+This directory contains every version of the objects in a versioned bucket. It
+groups keys into prefixes the same way as the bucket does, and includes prefixes
+whose objects have all been deleted.
+
+Keys whose latest version is a delete marker are hidden by default. You can show
+them by adding
+
+aws:
+  s3:
+    show_deleted: true
+
+to Wash's config file. Then you can find the objects that were deleted in the
+last day with something like
+
+find .versions -meta .Deleted -true -mtime -1d
+`
+
+const s3ObjectVersionsDescription = `
+This directory contains all versions of an S3 object, including any delete
+markers. Its mtime is when the latest version was created, which is when the
+object was deleted if the latest version is a delete marker.
+`
+
+const s3ObjectVersionDescription = `
+This is a version of an S3 object, named by its version ID. You can read it
+(e.g. with 'cat') to see the object's content at that version.
+`
+
+const s3DeleteMarkerDescription = `
+This is a delete marker, which S3 creates when an object is deleted from a
+versioned bucket.
+`
@@ -142,6 +142,38 @@ func getCachedEntry(path string) Entry {
 	return nil
 }
 
+// missingOpName is the cache category for children that were looked up but not found.
+const missingOpName = "Missing"
+
+// IsMissing returns whether the parent's child cname was recently looked up and not found,
+// as recorded by SetMissing.
+func IsMissing(p Parent, cname string) bool {
+	value, _ := cache.Get(missingOpName, childID(p.eb().id, cname))
+	return value != nil
+}
+
+// SetMissing records that the parent has no child cname for as long as the parent's List
+// result is cached. Shells and editors look up lots of files that don't exist, like .git,
+// in every directory, so remembering them saves re-listing the parent once its cached List
+// result expires. The record's cleared along with the parent's cache, when the parent's List
+// result is refreshed, or when the child's created through Wash.
+func SetMissing(p Parent, cname string) {
+	ttl := p.eb().ttl[ListOp]
+	if ttl < 0 {
+		return
+	}
+	_, _ = cache.GetOrUpdate(missingOpName, childID(p.eb().id, cname), ttl, false, func() (interface{}, error) {
+		return true, nil
+	})
+}
+
+// clearMissing clears the parent's records of missing children. They were made from an older
+// List result, so they'd otherwise outlive the refreshed one.
+func clearMissing(p Parent) []string {
+	expr := "^" + regexp.QuoteMeta(missingOpName+"::"+strings.TrimRight(p.eb().id, "/")+"/") + "[^/]+$"
+	return cache.Delete(regexp.MustCompile(expr))
+}
+
 // returns (parentID, cname)
 func splitID(entryID string) (string, string) {
 	segments := strings.Split(entryID, "/")
@@ -213,6 +245,14 @@ func (c DuplicateCNameErr) Error() string {
 // CachedList returns a map of <entry_cname> => <entry_object> to optimize
 // querying a specific entry.
 func cachedList(ctx context.Context, p Parent) (*EntryMap, error) {
+	if id := p.eb().id; id != "" && p.eb().ttl[ListOp] >= 0 {
+		// The List result's about to be refreshed, so forget the children that were missing
+		// from the old one.
+		if entries, _ := cache.Get(defaultOpCodeToNameMap[ListOp], id); entries == nil {
+			clearMissing(p)
+		}
+	}
+
 	cachedEntries, err := cachedDefaultOp(ctx, ListOp, p, func() (interface{}, error) {
 		// Including the entry's ID allows plugin authors to use any Cached* methods defined on the
 		// children after their creation. This is necessary when the child's Cached* methods are used
@@ -273,7 +313,7 @@ func cachedRead(ctx context.Context, e Entry) (entryContent, error) {
 			if err != nil {
 				return nil, err
 			}
-			return newEntryContent(rawContent), nil
+			return newReadableEntryContent(rawContent)
 		case BlockReadableSignature:
 			var readFunc blockReadFunc
 			switch t := e.(type) {
@@ -356,6 +396,9 @@ func cachedOp(ctx context.Context, opName string, entry Entry, ttl time.Duration
 }
 
 func setChildID(parentID string, child Entry) {
-	id := strings.TrimRight(parentID, "/") + "/" + CName(child)
-	child.eb().id = id
+	child.eb().id = childID(parentID, CName(child))
+}
+
+func childID(parentID string, cname string) string {
+	return strings.TrimRight(parentID, "/") + "/" + cname
 }
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/emirpasic/gods/maps/linkedhashmap"
+	"github.com/puppetlabs/wash/datastore"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -355,6 +356,8 @@ func (suite *CacheTestSuite) TestCachedListDefaultOp() {
 	mockChildren := []Entry{newCacheTestsMockEntry("mockChild")}
 	mungedOpValue := newEntryMap()
 	mungedOpValue.mp = toMap(mockChildren)
+	suite.cache.On("Get", "List", "id").Return(nil, nil)
+	suite.cache.On("Delete", regexp.MustCompile("^Missing::id/[^/]+$")).Return([]string{})
 	suite.testCachedDefaultOp(ListOp, "List", mockChildren, mungedOpValue, func(ctx context.Context, e Entry) (interface{}, error) {
 		return cachedList(ctx, e.(Parent))
 	})
@@ -565,6 +568,59 @@ func (suite *CacheTestSuite) TestCachedMetadata() {
 	})
 }
 
+func (suite *CacheTestSuite) TestSetMissing() {
+	entry := newCacheTestsMockEntry("foo")
+	entry.SetTestID("/foo")
+	entry.SetTTLOf(ListOp, 30*time.Second)
+
+	suite.cache.On("GetOrUpdate", "Missing", "/foo/bar", 30*time.Second, false, mock.Anything).Return(true, nil)
+	SetMissing(entry, "bar")
+	suite.cache.AssertExpectations(suite.T())
+
+	// Nothing's remembered if the parent's List result isn't cached.
+	entry.DisableCachingFor(ListOp)
+	SetMissing(entry, "baz")
+	suite.cache.AssertNumberOfCalls(suite.T(), "GetOrUpdate", 1)
+}
+
+func (suite *CacheTestSuite) TestCachedListClearsMissing() {
+	UnsetTestCache()
+	SetTestCache(datastore.NewMemCache())
+
+	ctx := context.Background()
+	entry := newCacheTestsMockEntry("foo")
+	entry.SetTestID("/foo")
+	entry.SetTTLOf(ListOp, time.Minute)
+	entry.On("List", mock.Anything).Return([]Entry{}, nil)
+	child := newCacheTestsMockEntry("baz")
+	child.SetTestID("/foo/baz")
+	child.SetTTLOf(ListOp, time.Minute)
+
+	SetMissing(entry, "bar")
+	SetMissing(child, "qux")
+	_, err := cachedList(ctx, entry)
+	suite.NoError(err)
+	suite.False(IsMissing(entry, "bar"))
+	suite.True(IsMissing(child, "qux"))
+
+	// Records made after the List result's refreshed last as long as it's cached.
+	SetMissing(entry, "bar")
+	_, err = cachedList(ctx, entry)
+	suite.NoError(err)
+	suite.True(IsMissing(entry, "bar"))
+	entry.AssertNumberOfCalls(suite.T(), "List", 1)
+}
+
+func (suite *CacheTestSuite) TestIsMissing() {
+	entry := newCacheTestsMockEntry("foo")
+	entry.SetTestID("/foo")
+
+	suite.cache.On("Get", "Missing", "/foo/bar").Return(true, nil)
+	suite.cache.On("Get", "Missing", "/foo/baz").Return(nil, nil)
+	suite.True(IsMissing(entry, "bar"))
+	suite.False(IsMissing(entry, "baz"))
+}
+
 func (suite *CacheTestSuite) TestSplitID() {
 	parentID, cname := splitID("/a/b")
 	suite.Equal("/a", parentID)
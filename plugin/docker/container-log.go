@@ -1,7 +1,6 @@
 package docker
 
 import (
-	"bytes"
 	"context"
 	"io"
 
@@ -41,32 +40,22 @@ func (clf *containerLogFile) Schema() *plugin.EntrySchema {
 	return plugin.NewEntrySchema(clf, "log").IsSingleton()
 }
 
-func (clf *containerLogFile) Read(ctx context.Context) ([]byte, error) {
+// ReadStream streams the log rather than reading it into memory because container logs can
+// be very large. Docker doesn't report the log's size, so ls and stat report it as 0. Reads
+// through the FUSE filesystem still return the whole log.
+func (clf *containerLogFile) ReadStream(ctx context.Context) (io.ReadCloser, error) {
 	opts := types.ContainerLogsOptions{ShowStdout: true, ShowStderr: true}
-	rdr, err := clf.client.ContainerLogs(ctx, clf.containerName, opts)
-	if err != nil {
-		return nil, err
-	}
-
-	var buf bytes.Buffer
-	var n int64
-	if clf.isTty(ctx) {
-		if n, err = buf.ReadFrom(rdr); err != nil {
-			return nil, err
-		}
-	} else {
-		// Write stdout and stderr to the same buffer.
-		if n, err = stdcopy.StdCopy(&buf, &buf, rdr); err != nil {
-			return nil, err
-		}
-	}
-	activity.Record(ctx, "Read %v bytes of %v log", n, clf.containerName)
-
-	return buf.Bytes(), nil
+	return clf.logs(ctx, opts)
 }
 
 func (clf *containerLogFile) Stream(ctx context.Context) (io.ReadCloser, error) {
 	opts := types.ContainerLogsOptions{ShowStdout: true, ShowStderr: true, Follow: true, Tail: "10"}
+	return clf.logs(ctx, opts)
+}
+
+// logs returns the container's logs. Docker multiplexes stdout and stderr when the container
+// doesn't have a TTY, so they're combined.
+func (clf *containerLogFile) logs(ctx context.Context, opts types.ContainerLogsOptions) (io.ReadCloser, error) {
 	rdr, err := clf.client.ContainerLogs(ctx, clf.containerName, opts)
 	if err != nil {
 		return nil, err
@@ -78,10 +67,13 @@ func (clf *containerLogFile) Stream(ctx context.Context) (io.ReadCloser, error)
 
 	r, w := io.Pipe()
 	go func() {
-		if _, err = stdcopy.StdCopy(w, w, rdr); err != nil {
+		if _, err := stdcopy.StdCopy(w, w, rdr); err != nil {
 			activity.Record(ctx, "Errored reading container %v: %v", clf.containerName, err)
 		}
 		activity.Record(ctx, "Closing write pipe: %v", w.Close())
 	}()
-	return r, nil
+	// Closing the pipe stops StdCopy, but the log response needs to be closed too.
+	return plugin.CleanupReader{ReadCloser: r, Cleanup: func() {
+		activity.Record(ctx, "Closing %v log: %v", clf.containerName, rdr.Close())
+	}}, nil
 }
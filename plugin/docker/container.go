@@ -18,6 +18,7 @@ import (
 type container struct {
 	plugin.EntryBase
 	id     string
+	mounts []types.MountPoint
 	client *client.Client
 }
 
@@ -35,6 +36,7 @@ func newContainer(inst types.Container, client *client.Client) *container {
 		EntryBase: plugin.NewEntry(name),
 	}
 	cont.id = inst.ID
+	cont.mounts = inst.Mounts
 	cont.client = client
 
 	startTime := time.Unix(inst.Created, 0)
@@ -77,6 +79,7 @@ func (c *container) ChildSchemas() []*plugin.EntrySchema {
 		(&containerLogFile{}).Schema(),
 		(&plugin.MetadataJSONFile{}).Schema(),
 		(&vol.FS{}).Schema(),
+		(&containerVolumesDir{}).Schema(),
 	}
 }
 
@@ -91,7 +94,7 @@ func (c *container) List(ctx context.Context) ([]plugin.Entry, error) {
 
 	// Include a view of the remote filesystem using volume.FS. Use a small maxdepth because
 	// VMs can have lots of files and Exec is fast.
-	return []plugin.Entry{clf, cm, vol.NewFS(ctx, "fs", c, 3)}, nil
+	return []plugin.Entry{clf, cm, vol.NewFS(ctx, "fs", c, 3), newContainerVolumesDir(c.mounts)}, nil
 }
 
 func (c *container) Delete(ctx context.Context) (bool, error) {
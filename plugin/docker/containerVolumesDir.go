@@ -0,0 +1,54 @@
+package docker
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// containerVolumesDir links to the named volumes that a container mounts.
+type containerVolumesDir struct {
+	plugin.EntryBase
+	mounts []types.MountPoint
+}
+
+func newContainerVolumesDir(mounts []types.MountPoint) *containerVolumesDir {
+	vd := &containerVolumesDir{
+		EntryBase: plugin.NewEntry("volumes"),
+	}
+	vd.mounts = mounts
+	// The mounts are known when the container's listed.
+	vd.DisableDefaultCaching()
+	return vd
+}
+
+func (vd *containerVolumesDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(vd, "volumes").
+		IsSingleton().
+		SetDescription(containerVolumesDirDescription)
+}
+
+func (vd *containerVolumesDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&plugin.Link{}).Schema(),
+	}
+}
+
+func (vd *containerVolumesDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var links []plugin.Entry
+	for _, m := range vd.mounts {
+		if m.Type != mount.TypeVolume || m.Name == "" {
+			continue
+		}
+		// The link's in docker/containers/<container>/volumes.
+		links = append(links, plugin.NewLink(m.Name, "../../../volumes/"+m.Name))
+	}
+	return links, nil
+}
+
+const containerVolumesDirDescription = `
+This contains links to the Docker volumes that the container mounts. Bind
+mounts aren't included because they're not Docker volumes.
+`
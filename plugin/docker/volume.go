@@ -4,7 +4,7 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
-	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
@@ -66,6 +66,14 @@ func (v *volume) List(ctx context.Context) ([]plugin.Entry, error) {
 	return volpkg.List(ctx, v)
 }
 
+func (v *volume) Create(ctx context.Context, name string, content []byte) (plugin.Entry, error) {
+	return volpkg.Create(ctx, v, name, content)
+}
+
+func (v *volume) CreateDir(ctx context.Context, name string) (plugin.Parent, error) {
+	return volpkg.CreateDir(ctx, v, name)
+}
+
 func (v *volume) Delete(ctx context.Context) (bool, error) {
 	err := v.client.VolumeRemove(ctx, v.Name(), true)
 	return true, err
@@ -174,11 +182,22 @@ func (v *volume) runInTemporaryContainer(ctx context.Context, cmd []string) ([]b
 		return nil, err
 	}
 	if statusCode != 0 {
-		return nil, errors.New(strings.Trim(string(bytes), "\n"))
+		return nil, containerExitError{statusCode: statusCode, output: strings.Trim(string(bytes), "\n")}
 	}
 	return bytes, nil
 }
 
+// containerExitError is returned when a temporary container's command exits with a non-zero
+// status. Its message is the command's output.
+type containerExitError struct {
+	statusCode int64
+	output     string
+}
+
+func (e containerExitError) Error() string {
+	return e.output
+}
+
 func (v *volume) VolumeList(ctx context.Context, path string) (volpkg.DirMap, error) {
 	// Use a larger maxdepth because volumes have relatively few files and VolumeList is slow.
 	maxdepth := 10
@@ -278,11 +297,28 @@ func (v *volume) VolumeDelete(ctx context.Context, path string) (bool, error) {
 	return true, nil
 }
 
+func (v *volume) VolumeMkdir(ctx context.Context, path string) error {
+	_, err := v.runInTemporaryContainer(ctx, []string{"mkdir", mountpoint + path})
+	return err
+}
+
+func (v *volume) VolumeRename(ctx context.Context, oldPath string, newPath string) error {
+	_, err := v.runInTemporaryContainer(ctx, volpkg.RenameCmdPOSIX(mountpoint+oldPath, mountpoint+newPath))
+	if exerr, ok := err.(containerExitError); ok {
+		if errno, ok := volpkg.RenameErrno(int(exerr.statusCode)); ok {
+			return fmt.Errorf("could not move %v to %v: %w", oldPath, newPath, errno)
+		}
+	}
+	return err
+}
+
 const volumeDescription = `
 This is a Docker volume. We create a temporary Docker container whenever
 Wash invokes a currently uncached List/Read/Stream action on it or one of
 its children. For List, we run 'find -exec stat' on the container and parse
 its output. For Read, we run 'sleep 60' then proceed to download the file
 content from the container. For Stream, we run 'tail -f' and pass over its
-output.
+output. New files are uploaded the same way as writes, and new directories
+are created by running 'mkdir'. Files and directories are moved by running
+'mv' after checking that it won't move them into an existing directory.
 `
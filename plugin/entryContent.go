@@ -2,7 +2,10 @@ package plugin
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
 )
 
 // entryContent is the cached result of a Read invocation
@@ -69,3 +72,117 @@ func (c *blockReadableEntryContent) read(ctx context.Context, size int64, offset
 func (c *blockReadableEntryContent) size() uint64 {
 	return c.sz
 }
+
+// ContentOpts configures how Readable entries' content is cached.
+type ContentOpts struct {
+	// MaxCachedSize is the largest content, in bytes, that's cached in memory. Larger content
+	// is spilled to a temporary file in SpillDir. 0 caches all content in memory. Spilling
+	// only bounds the memory used by the cache; Read still returns all of the content at once,
+	// so entries that are too large to hold in memory should be SequentialReadable.
+	MaxCachedSize uint64
+	// SpillDir is where content larger than MaxCachedSize is stored. It defaults to the
+	// system's temporary directory.
+	SpillDir string
+}
+
+var contentOpts ContentOpts
+
+// SetContentOpts configures how Readable entries' content is cached. Call it before
+// InitCache.
+func SetContentOpts(opts ContentOpts) {
+	contentOpts = opts
+}
+
+// newReadableEntryContent returns the content of a Readable entry, spilling it to disk if
+// it's larger than ContentOpts.MaxCachedSize. The content's already in memory by then, so
+// spilling it doesn't lower the peak memory used to read it.
+func newReadableEntryContent(content []byte) (entryContent, error) {
+	if max := contentOpts.MaxCachedSize; max == 0 || uint64(len(content)) <= max {
+		return newEntryContent(content), nil
+	}
+	return newSpilledEntryContent(content, contentOpts.SpillDir)
+}
+
+// spilledEntryContent is the implementation of entryContent for Readable entries
+// whose content is too large to cache in memory. The content's stored in a temporary
+// file that's removed as soon as it's written, so its disk space is freed when the
+// file's closed. That happens when the content's garbage collected after it's evicted
+// from the cache, or when Wash exits.
+type spilledEntryContent struct {
+	file *os.File
+	sz   uint64
+}
+
+func newSpilledEntryContent(content []byte, dir string) (*spilledEntryContent, error) {
+	file, err := ioutil.TempFile(dir, "wash-content-")
+	if err != nil {
+		return nil, fmt.Errorf("could not create a file to store the content: %w", err)
+	}
+	if err := os.Remove(file.Name()); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("could not unlink %v: %w", file.Name(), err)
+	}
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("could not store the content: %w", err)
+	}
+	return &spilledEntryContent{file: file, sz: uint64(len(content))}, nil
+}
+
+func (c *spilledEntryContent) read(_ context.Context, size int64, offset int64) ([]byte, error) {
+	contentSize := int64(c.sz)
+	if offset >= contentSize {
+		return []byte{}, io.EOF
+	}
+	var err error
+	if offset+size > contentSize {
+		size = contentSize - offset
+		err = io.EOF
+	}
+	data := make([]byte, size)
+	if _, readErr := c.file.ReadAt(data, offset); readErr != nil {
+		return nil, readErr
+	}
+	return data, err
+}
+
+func (c *spilledEntryContent) size() uint64 {
+	return c.sz
+}
+
+// sequentialEntryContent is the implementation of entryContent for
+// SequentialReadable entries. It doesn't cache any content, so each read
+// streams the content from the start.
+type sequentialEntryContent struct {
+	entry SequentialReadable
+}
+
+func (c sequentialEntryContent) read(ctx context.Context, size int64, offset int64) ([]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	rdr, err := c.entry.ReadStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rdr.Close()
+	return ReadFromStream(rdr, size, offset)
+}
+
+// The size of sequential content isn't known without reading all of it.
+func (c sequentialEntryContent) size() uint64 {
+	return 0
+}
+
+// ReadFromStream skips offset bytes of a stream, then reads up to size bytes. Like
+// plugin.Read, it returns io.EOF if it reaches the end of the stream.
+func ReadFromStream(rdr io.Reader, size int64, offset int64) ([]byte, error) {
+	if _, err := io.CopyN(ioutil.Discard, rdr, offset); err != nil {
+		return []byte{}, err
+	}
+	data := make([]byte, size)
+	n, err := io.ReadFull(rdr, data)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return data[:n], err
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -102,6 +105,56 @@ func (s *EntryContentTestSuite) TestBlockReadableEntryContent_Read_ErroredReadFu
 	s.Equal(expectedErr, err)
 }
 
+func (s *EntryContentTestSuite) TestNewReadableEntryContent() {
+	defer SetContentOpts(ContentOpts{})
+	rawContent := []byte("some raw content")
+
+	content, err := newReadableEntryContent(rawContent)
+	if s.NoError(err) {
+		s.IsType(&entryContentImpl{}, content)
+	}
+
+	spillDir, err := ioutil.TempDir("", "wash-spill")
+	if !s.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(spillDir)
+	SetContentOpts(ContentOpts{MaxCachedSize: 4, SpillDir: spillDir})
+	content, err = newReadableEntryContent(rawContent)
+	if !s.NoError(err) || !s.IsType(&spilledEntryContent{}, content) {
+		return
+	}
+	// The file's removed as soon as it's written.
+	files, err := ioutil.ReadDir(spillDir)
+	s.NoError(err)
+	s.Empty(files)
+
+	s.Equal(uint64(len(rawContent)), content.size())
+	data, err := content.read(context.Background(), 4, 2)
+	s.NoError(err)
+	s.Equal([]byte("me r"), data)
+	data, err = content.read(context.Background(), 100, 10)
+	s.Equal(io.EOF, err)
+	s.Equal([]byte("ontent"), data)
+	data, err = content.read(context.Background(), 1, 100)
+	s.Equal(io.EOF, err)
+	s.Equal([]byte{}, data)
+}
+
+func (s *EntryContentTestSuite) TestReadFromStream() {
+	data, err := ReadFromStream(strings.NewReader("some raw content"), 4, 2)
+	s.NoError(err)
+	s.Equal([]byte("me r"), data)
+
+	data, err = ReadFromStream(strings.NewReader("some raw content"), 100, 10)
+	s.Equal(io.EOF, err)
+	s.Equal([]byte("ontent"), data)
+
+	data, err = ReadFromStream(strings.NewReader("some raw content"), 1, 100)
+	s.Equal(io.EOF, err)
+	s.Empty(data)
+}
+
 func TestEntryContent(t *testing.T) {
 	suite.Run(t, new(EntryContentTestSuite))
 }
@@ -15,7 +15,8 @@ type coreEntry interface {
 }
 
 var coreEntries = map[string]coreEntry{
-	"__volume::fs__": volumeFS{},
+	"__volume::fs__":   volumeFS{},
+	"__plugin::link__": link{},
 }
 
 type volumeFS struct{}
@@ -35,3 +36,21 @@ func (volumeFS) createInstance(ctx context.Context, parent *pluginEntry, e decod
 func (volumeFS) template() plugin.Entry {
 	return &volume.FS{}
 }
+
+type link struct{}
+
+func (link) createInstance(ctx context.Context, parent *pluginEntry, e decodedExternalPluginEntry) (plugin.Entry, error) {
+	var opts struct{ Target string }
+	if err := json.Unmarshal([]byte(e.State), &opts); err != nil {
+		return nil, fmt.Errorf("link options invalid: %v", err)
+	}
+	if opts.Target == "" {
+		return nil, fmt.Errorf("link options invalid: the target must be specified")
+	}
+
+	return plugin.NewLink(e.Name, opts.Target), nil
+}
+
+func (link) template() plugin.Entry {
+	return &plugin.Link{}
+}
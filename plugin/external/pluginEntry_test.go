@@ -697,7 +697,7 @@ func (suite *ExternalPluginEntryTestSuite) TestListWithCoreEntry() {
 		suite.Equal([]string{"list"}, plugin.SupportedActionsOf(entries[0]))
 		suite.Equal("bar", plugin.Name(entries[0]))
 
-		suite.Equal([]string{"list"}, plugin.SupportedActionsOf(entries[1]))
+		suite.ElementsMatch([]string{"list", "create", "mkdir"}, plugin.SupportedActionsOf(entries[1]))
 		suite.Equal("fs1", plugin.Name(entries[1]))
 		suite.IsType(&volume.FS{}, entries[1])
 	}
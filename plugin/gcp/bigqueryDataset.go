@@ -0,0 +1,60 @@
+package gcp
+
+import (
+	"context"
+
+	"github.com/puppetlabs/wash/plugin"
+	bigquery "google.golang.org/api/bigquery/v2"
+)
+
+type bigqueryDataset struct {
+	plugin.EntryBase
+	service bigqueryProjectService
+	id      string
+}
+
+func newBigqueryDataset(dataset *bigquery.DatasetListDatasets, service bigqueryProjectService) *bigqueryDataset {
+	ds := &bigqueryDataset{
+		EntryBase: plugin.NewEntry(dataset.DatasetReference.DatasetId),
+		service:   service,
+		id:        dataset.DatasetReference.DatasetId,
+	}
+	ds.SetPartialMetadata(dataset)
+	return ds
+}
+
+func (d *bigqueryDataset) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	dataset, err := d.service.Datasets.Get(d.service.projectID, d.id).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return plugin.ToJSONObject(dataset), nil
+}
+
+func (d *bigqueryDataset) List(ctx context.Context) ([]plugin.Entry, error) {
+	var entries []plugin.Entry
+	err := d.service.Tables.List(d.service.projectID, d.id).Pages(ctx, func(resp *bigquery.TableList) error {
+		for _, table := range resp.Tables {
+			entries = append(entries, newBigqueryTable(table, d.service))
+		}
+		return nil
+	})
+	return entries, err
+}
+
+func (d *bigqueryDataset) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(d, "dataset").
+		SetDescription(bigqueryDatasetDescription).
+		SetPartialMetadataSchema(bigquery.DatasetListDatasets{}).
+		SetMetadataSchema(bigquery.Dataset{})
+}
+
+func (d *bigqueryDataset) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&bigqueryTable{}).Schema(),
+	}
+}
+
+const bigqueryDatasetDescription = `
+This is a BigQuery dataset. It contains the dataset's tables and views.
+`
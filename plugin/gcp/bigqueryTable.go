@@ -0,0 +1,149 @@
+package gcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/puppetlabs/wash/plugin"
+	bigquery "google.golang.org/api/bigquery/v2"
+)
+
+// bigqueryTableRows is the number of rows that are read from a table.
+const bigqueryTableRows = 100
+
+type bigqueryTable struct {
+	plugin.EntryBase
+	service   bigqueryProjectService
+	datasetID string
+	id        string
+}
+
+func newBigqueryTable(table *bigquery.TableListTables, service bigqueryProjectService) *bigqueryTable {
+	tbl := &bigqueryTable{
+		EntryBase: plugin.NewEntry(table.TableReference.TableId),
+		service:   service,
+		datasetID: table.TableReference.DatasetId,
+		id:        table.TableReference.TableId,
+	}
+	// CreationTime is in milliseconds since the epoch.
+	tbl.
+		SetPartialMetadata(table).
+		Attributes().
+		SetCrtime(time.Unix(0, table.CreationTime*int64(time.Millisecond)))
+	return tbl
+}
+
+func (t *bigqueryTable) get(ctx context.Context) (*bigquery.Table, error) {
+	return t.service.Tables.Get(t.service.projectID, t.datasetID, t.id).Context(ctx).Do()
+}
+
+// Metadata includes the table's schema.
+func (t *bigqueryTable) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	table, err := t.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return plugin.ToJSONObject(table), nil
+}
+
+// Read returns the table's first rows as JSON lines.
+func (t *bigqueryTable) Read(ctx context.Context) ([]byte, error) {
+	table, err := t.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var fields []*bigquery.TableFieldSchema
+	if table.Schema != nil {
+		fields = table.Schema.Fields
+	}
+
+	data, err := t.service.Tabledata.
+		List(t.service.projectID, t.datasetID, t.id).
+		MaxResults(bigqueryTableRows).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, row := range data.Rows {
+		cells := make([]interface{}, len(row.F))
+		for i, cell := range row.F {
+			cells[i] = map[string]interface{}{"v": cell.V}
+		}
+		if err := enc.Encode(bigqueryRecord(fields, cells)); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// bigqueryRecord converts a row or RECORD value from the API's {"f": [{"v": value}]} format
+// to an object keyed by the fields' names.
+func bigqueryRecord(fields []*bigquery.TableFieldSchema, cells []interface{}) map[string]interface{} {
+	record := make(map[string]interface{}, len(fields))
+	for i, field := range fields {
+		if i >= len(cells) {
+			break
+		}
+		record[field.Name] = bigqueryValue(field, bigqueryCellValue(cells[i]))
+	}
+	return record
+}
+
+func bigqueryValue(field *bigquery.TableFieldSchema, value interface{}) interface{} {
+	if field.Mode == "REPEATED" {
+		items, ok := value.([]interface{})
+		if !ok {
+			return value
+		}
+		values := make([]interface{}, len(items))
+		for i, item := range items {
+			values[i] = bigqueryScalar(field, bigqueryCellValue(item))
+		}
+		return values
+	}
+	return bigqueryScalar(field, value)
+}
+
+func bigqueryScalar(field *bigquery.TableFieldSchema, value interface{}) interface{} {
+	if field.Type != "RECORD" && field.Type != "STRUCT" {
+		return value
+	}
+	record, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	cells, _ := record["f"].([]interface{})
+	return bigqueryRecord(field.Fields, cells)
+}
+
+func bigqueryCellValue(cell interface{}) interface{} {
+	if c, ok := cell.(map[string]interface{}); ok {
+		return c["v"]
+	}
+	return nil
+}
+
+func (t *bigqueryTable) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(t, "table").
+		SetDescription(bigqueryTableDescription).
+		SetPartialMetadataSchema(bigquery.TableListTables{}).
+		SetMetadataSchema(bigquery.Table{})
+}
+
+const bigqueryTableDescription = `
+This is a BigQuery table or view. Its metadata includes the table's schema, so
+you can see its columns with something like
+
+  meta -o json table | jq .schema.fields
+
+Reading a table returns its first 100 rows as JSON lines, where each line is an
+object keyed by column name. BigQuery returns scalar values as strings, so
+numbers and booleans are strings too. Views can't be read because their rows
+are only available by running a query.
+`
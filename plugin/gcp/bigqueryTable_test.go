@@ -0,0 +1,39 @@
+package gcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bigquery "google.golang.org/api/bigquery/v2"
+)
+
+func TestBigqueryRecord(t *testing.T) {
+	fields := []*bigquery.TableFieldSchema{
+		{Name: "name", Type: "STRING"},
+		{Name: "tags", Type: "STRING", Mode: "REPEATED"},
+		{Name: "address", Type: "RECORD", Fields: []*bigquery.TableFieldSchema{
+			{Name: "city", Type: "STRING"},
+			{Name: "zip", Type: "INTEGER"},
+		}},
+		{Name: "missing", Type: "STRING"},
+	}
+	row := `{"f": [
+		{"v": "foo"},
+		{"v": [{"v": "a"}, {"v": "b"}]},
+		{"v": {"f": [{"v": "Portland"}, {"v": "97201"}]}},
+		{"v": null}
+	]}`
+	var decoded struct {
+		F []interface{} `json:"f"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(row), &decoded))
+
+	assert.Equal(t, map[string]interface{}{
+		"name":    "foo",
+		"tags":    []interface{}{"a", "b"},
+		"address": map[string]interface{}{"city": "Portland", "zip": "97201"},
+		"missing": nil,
+	}, bigqueryRecord(fields, decoded.F))
+}
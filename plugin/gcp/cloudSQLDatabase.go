@@ -0,0 +1,29 @@
+package gcp
+
+import (
+	"github.com/puppetlabs/wash/plugin"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+type cloudSQLDatabase struct {
+	plugin.EntryBase
+}
+
+func newCloudSQLDatabase(database *sqladmin.Database) *cloudSQLDatabase {
+	db := &cloudSQLDatabase{
+		EntryBase: plugin.NewEntry(database.Name),
+	}
+	db.SetPartialMetadata(database)
+	return db
+}
+
+func (d *cloudSQLDatabase) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(d, "database").
+		SetDescription(cloudSQLDatabaseDescription).
+		SetPartialMetadataSchema(sqladmin.Database{})
+}
+
+const cloudSQLDatabaseDescription = `
+This is a database in a Cloud SQL instance. Its metadata includes the
+database's charset and collation.
+`
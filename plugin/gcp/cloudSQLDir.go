@@ -0,0 +1,62 @@
+package gcp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/puppetlabs/wash/plugin"
+	"google.golang.org/api/option"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+type cloudSQLProjectService struct {
+	*sqladmin.Service
+	projectID string
+}
+
+type cloudSQLDir struct {
+	plugin.EntryBase
+	service cloudSQLProjectService
+}
+
+func newCloudSQLDir(ctx context.Context, client *http.Client, projID string) (*cloudSQLDir, error) {
+	svc, err := sqladmin.NewService(context.Background(), option.WithHTTPClient(client))
+	if err != nil {
+		return nil, err
+	}
+	sql := &cloudSQLDir{
+		EntryBase: plugin.NewEntry("cloud_sql"),
+		service:   cloudSQLProjectService{Service: svc, projectID: projID},
+	}
+	if _, err := plugin.List(ctx, sql); err != nil {
+		sql.MarkInaccessible(ctx, err)
+	}
+	return sql, nil
+}
+
+func (s *cloudSQLDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var entries []plugin.Entry
+	err := s.service.Instances.List(s.service.projectID).Pages(ctx, func(resp *sqladmin.InstancesListResponse) error {
+		for _, instance := range resp.Items {
+			entries = append(entries, newCloudSQLInstance(instance, s.service))
+		}
+		return nil
+	})
+	return entries, err
+}
+
+func (s *cloudSQLDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(s, "cloud_sql").
+		SetDescription(cloudSQLDirDescription).
+		IsSingleton()
+}
+
+func (s *cloudSQLDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&cloudSQLInstance{}).Schema(),
+	}
+}
+
+const cloudSQLDirDescription = `
+This directory contains the project's Cloud SQL instances.
+`
@@ -0,0 +1,87 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/puppetlabs/wash/plugin"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+type cloudSQLInstance struct {
+	plugin.EntryBase
+	service cloudSQLProjectService
+}
+
+func newCloudSQLInstance(instance *sqladmin.DatabaseInstance, service cloudSQLProjectService) *cloudSQLInstance {
+	inst := &cloudSQLInstance{
+		EntryBase: plugin.NewEntry(instance.Name),
+		service:   service,
+	}
+	inst.
+		// The instance's state changes as it's started and stopped.
+		SetTTLOf(plugin.ListOp, 30*time.Second).
+		SetPartialMetadata(instance)
+	return inst
+}
+
+func (i *cloudSQLInstance) List(ctx context.Context) ([]plugin.Entry, error) {
+	resp, err := i.service.Databases.List(i.service.projectID, i.Name()).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, len(resp.Items))
+	for j, database := range resp.Items {
+		entries[j] = newCloudSQLDatabase(database)
+	}
+	return entries, nil
+}
+
+func (i *cloudSQLInstance) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(i, "instance").
+		SetDescription(cloudSQLInstanceDescription).
+		SetPartialMetadataSchema(sqladmin.DatabaseInstance{}).
+		AddSignal("start", "Starts the instance").
+		AddSignal("stop", "Stops the instance").
+		AddSignal("restart", "Restarts the instance")
+}
+
+func (i *cloudSQLInstance) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&cloudSQLDatabase{}).Schema(),
+	}
+}
+
+func (i *cloudSQLInstance) Signal(ctx context.Context, signal string) error {
+	var err error
+	switch signal {
+	case "start":
+		err = i.setActivationPolicy(ctx, "ALWAYS")
+	case "stop":
+		err = i.setActivationPolicy(ctx, "NEVER")
+	case "restart":
+		_, err = i.service.Instances.Restart(i.service.projectID, i.Name()).Context(ctx).Do()
+	default:
+		err = fmt.Errorf("unsupported signal %v", signal)
+	}
+	return err
+}
+
+// setActivationPolicy starts or stops the instance. Cloud SQL doesn't have start and stop
+// operations; instead an instance runs when its activation policy is ALWAYS and stops when
+// it's NEVER.
+func (i *cloudSQLInstance) setActivationPolicy(ctx context.Context, policy string) error {
+	patch := &sqladmin.DatabaseInstance{
+		Settings: &sqladmin.Settings{ActivationPolicy: policy},
+	}
+	_, err := i.service.Instances.Patch(i.service.projectID, i.Name(), patch).Context(ctx).Do()
+	return err
+}
+
+const cloudSQLInstanceDescription = `
+This is a Cloud SQL instance. It contains the instance's databases. It can be
+started, stopped and restarted with the start, stop and restart signals.
+Starting and stopping the instance changes its activation policy to ALWAYS and
+NEVER respectively.
+`
@@ -11,13 +11,15 @@ type firestoreCollection struct {
 	plugin.EntryBase
 	client *firestore.Client
 	path   string
+	opts   firestoreOptions
 }
 
-func newFirestoreCollection(client *firestore.Client, parent string, collRef *firestore.CollectionRef) *firestoreCollection {
+func newFirestoreCollection(client *firestore.Client, parent string, collRef *firestore.CollectionRef, opts firestoreOptions) *firestoreCollection {
 	return &firestoreCollection{
 		EntryBase: plugin.NewEntry(collRef.ID),
 		client:    client,
 		path:      firestorePath(parent, collRef.ID),
+		opts:      opts,
 	}
 }
 
@@ -28,7 +30,7 @@ func (coll *firestoreCollection) List(ctx context.Context) ([]plugin.Entry, erro
 	}
 	entries := make([]plugin.Entry, len(docs))
 	for ix, doc := range docs {
-		entries[ix] = newFirestoreDocument(coll.client, coll.path, doc)
+		entries[ix] = newFirestoreDocument(coll.client, coll.path, doc, coll.opts)
 	}
 	return entries, nil
 }
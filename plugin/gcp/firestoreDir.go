@@ -4,15 +4,17 @@ import (
 	"context"
 
 	"cloud.google.com/go/firestore"
+	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/plugin"
 )
 
 type firestoreDir struct {
 	plugin.EntryBase
 	client *firestore.Client
+	opts   firestoreOptions
 }
 
-func newFirestoreDir(ctx context.Context, projID string) (*firestoreDir, error) {
+func newFirestoreDir(ctx context.Context, projID string, opts firestoreOptions) (*firestoreDir, error) {
 	cli, err := firestore.NewClient(context.Background(), projID)
 	if err != nil {
 		return nil, err
@@ -20,6 +22,7 @@ func newFirestoreDir(ctx context.Context, projID string) (*firestoreDir, error)
 	f := &firestoreDir{
 		EntryBase: plugin.NewEntry("firestore"),
 		client:    cli,
+		opts:      opts,
 	}
 	if _, err := plugin.List(ctx, f); err != nil {
 		f.MarkInaccessible(ctx, err)
@@ -27,13 +30,25 @@ func newFirestoreDir(ctx context.Context, projID string) (*firestoreDir, error)
 	return f, nil
 }
 
-// List all collections as dirs
+// List all collections as dirs, and a directory of saved queries if there are any.
 func (f *firestoreDir) List(ctx context.Context) ([]plugin.Entry, error) {
 	colls, err := f.client.Collections(ctx).GetAll()
 	if err != nil {
 		return nil, err
 	}
-	return toCollectionEntries(f.client, "", colls), nil
+	entries := toCollectionEntries(f.client, "", colls, f.opts)
+	if len(f.opts.queries) == 0 {
+		return entries, nil
+	}
+
+	// Don't hide a collection that has the same name as the queries directory.
+	for _, coll := range colls {
+		if coll.ID == firestoreQueriesDirName {
+			activity.Record(ctx, "Omitting saved queries because there's a %v collection", firestoreQueriesDirName)
+			return entries, nil
+		}
+	}
+	return append(entries, newFirestoreQueriesDir(f.client, f.opts)), nil
 }
 
 func (f *firestoreDir) Schema() *plugin.EntrySchema {
@@ -45,13 +60,14 @@ func (f *firestoreDir) Schema() *plugin.EntrySchema {
 func (f *firestoreDir) ChildSchemas() []*plugin.EntrySchema {
 	return []*plugin.EntrySchema{
 		(&firestoreCollection{}).Schema(),
+		(&firestoreQueriesDir{}).Schema(),
 	}
 }
 
-func toCollectionEntries(client *firestore.Client, parent string, colls []*firestore.CollectionRef) []plugin.Entry {
+func toCollectionEntries(client *firestore.Client, parent string, colls []*firestore.CollectionRef, opts firestoreOptions) []plugin.Entry {
 	entries := make([]plugin.Entry, len(colls))
 	for ix, coll := range colls {
-		entries[ix] = newFirestoreCollection(client, parent, coll)
+		entries[ix] = newFirestoreCollection(client, parent, coll, opts)
 	}
 	return entries
 }
@@ -76,6 +92,21 @@ example, something like
 
 will return all documents in <collection> whose 'foo' field is equal to 5.
 
-NOTE: Filtering with find does not (yet) take advantage of Firestore queries. If you
-need that optimization, then please file an issue!
+NOTE: Filtering with find does not (yet) take advantage of Firestore queries. Instead,
+you can save queries in Wash's config file. For example, adding
+
+gcp:
+  firestore:
+    queries:
+      adults:
+        collection: users
+        where:
+          - {field: age, op: '>=', value: 18}
+        order_by: [age desc]
+        limit: 10
+
+to Wash's config file will add a queries/adults directory that contains the matching
+documents. Where clauses support the <, <=, >, >=, ==, array-contains, in and
+array-contains-any operators. The config file's keys are case-insensitive, so query
+names are lowercased.
 `
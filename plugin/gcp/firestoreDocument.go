@@ -1,8 +1,11 @@
 package gcp
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"sync"
 	"time"
 
 	"cloud.google.com/go/firestore"
@@ -11,9 +14,11 @@ import (
 
 type firestoreDocument struct {
 	plugin.EntryBase
-	client *firestore.Client
-	path   string
-	data   map[string]interface{}
+	client     *firestore.Client
+	path       string
+	data       map[string]interface{}
+	updateTime time.Time
+	opts       firestoreOptions
 }
 
 type firestoreDocumentMetadata struct {
@@ -23,12 +28,14 @@ type firestoreDocumentMetadata struct {
 	Data       map[string]interface{} `json:"Data"`
 }
 
-func newFirestoreDocument(client *firestore.Client, parent string, snapshot *firestore.DocumentSnapshot) *firestoreDocument {
+func newFirestoreDocument(client *firestore.Client, parent string, snapshot *firestore.DocumentSnapshot, opts firestoreOptions) *firestoreDocument {
 	doc := &firestoreDocument{
-		EntryBase: plugin.NewEntry(snapshot.Ref.ID),
-		client:    client,
-		path:      firestorePath(parent, snapshot.Ref.ID),
-		data:      snapshot.Data(),
+		EntryBase:  plugin.NewEntry(snapshot.Ref.ID),
+		client:     client,
+		path:       firestorePath(parent, snapshot.Ref.ID),
+		data:       snapshot.Data(),
+		updateTime: snapshot.UpdateTime,
+		opts:       opts,
 	}
 
 	metadata := firestoreDocumentMetadata{
@@ -51,11 +58,11 @@ func (doc *firestoreDocument) List(ctx context.Context) ([]plugin.Entry, error)
 	if err != nil {
 		return nil, err
 	}
-	dataJSON, err := newFirestoreDocumentDataJSON(doc.data)
+	dataJSON, err := newFirestoreDocumentDataJSON(doc.client, doc.path, doc.data, doc.updateTime, doc.opts.merge)
 	if err != nil {
 		return nil, err
 	}
-	collEntries := toCollectionEntries(doc.client, doc.path, colls)
+	collEntries := toCollectionEntries(doc.client, doc.path, colls, doc.opts)
 	return append([]plugin.Entry{dataJSON}, collEntries...), nil
 }
 
@@ -84,27 +91,160 @@ more details on why we have this kind of entry.
 
 type firestoreDocumentDataJSON struct {
 	plugin.EntryBase
-	bytes []byte
+	client *firestore.Client
+	path   string
+	merge  bool
+	// bytes, updateTime and the size attribute are updated after each write. Writes only
+	// succeed if the document's still at updateTime.
+	bytes      []byte
+	updateTime time.Time
+	mux        sync.Mutex
 }
 
-func newFirestoreDocumentDataJSON(data map[string]interface{}) (*firestoreDocumentDataJSON, error) {
+func newFirestoreDocumentDataJSON(client *firestore.Client, path string, data map[string]interface{}, updateTime time.Time, merge bool) (*firestoreDocumentDataJSON, error) {
 	dataBytes, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
 		// This should never happen
 		return nil, err
 	}
 	dataEntry := &firestoreDocumentDataJSON{
-		EntryBase: plugin.NewEntry("data.json"),
-		bytes:     dataBytes,
+		EntryBase:  plugin.NewEntry("data.json"),
+		client:     client,
+		path:       path,
+		merge:      merge,
+		bytes:      dataBytes,
+		updateTime: updateTime,
 	}
 	dataEntry.DisableDefaultCaching()
+	// Setting the size lets data.json be edited like a regular file.
+	dataEntry.Attributes().SetSize(uint64(len(dataBytes)))
 	return dataEntry, nil
 }
 
 func (data *firestoreDocumentDataJSON) Read(ctx context.Context) ([]byte, error) {
+	data.mux.Lock()
+	defer data.mux.Unlock()
 	return data.bytes, nil
 }
 
+// Write replaces the document's data with the written JSON object, or merges it into the
+// document's data if the merge option's set.
+func (data *firestoreDocumentDataJSON) Write(ctx context.Context, p []byte) error {
+	// Use json.Number so that integers are stored as integers instead of doubles.
+	decoder := json.NewDecoder(bytes.NewReader(p))
+	decoder.UseNumber()
+	var newData map[string]interface{}
+	if err := decoder.Decode(&newData); err != nil {
+		return fmt.Errorf("data.json must be a JSON object: %v", err)
+	}
+	if newData == nil {
+		return fmt.Errorf("data.json must be a JSON object, not null")
+	}
+	if decoder.More() {
+		return fmt.Errorf("data.json must be a single JSON object")
+	}
+	newData = fromJSONNumbers(newData).(map[string]interface{})
+
+	data.mux.Lock()
+	defer data.mux.Unlock()
+
+	// Firestore's update-time precondition only applies to updates of specific fields, so
+	// check the update time in a transaction instead.
+	ref := data.client.Doc(data.path)
+	err := data.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snapshot, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		if !snapshot.UpdateTime.Equal(data.updateTime) {
+			return firestoreDocumentModifiedErr{path: data.path, updateTime: snapshot.UpdateTime}
+		}
+		toWrite := preserveFirestoreValues(newData, snapshot.Data())
+		if data.merge {
+			return tx.Set(ref, toWrite, firestore.MergeAll)
+		}
+		return tx.Set(ref, toWrite)
+	})
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := ref.Get(ctx)
+	if err != nil {
+		return err
+	}
+	dataBytes, err := json.MarshalIndent(snapshot.Data(), "", "  ")
+	if err != nil {
+		return err
+	}
+	data.bytes = dataBytes
+	data.updateTime = snapshot.UpdateTime
+	// The entry's cached in its document's listing, so update its size for later reads.
+	data.Attributes().SetSize(uint64(len(dataBytes)))
+	return nil
+}
+
+// fromJSONNumbers converts the json.Numbers in v to int64s if they're integers and to
+// float64s otherwise.
+func fromJSONNumbers(v interface{}) interface{} {
+	switch t := v.(type) {
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		f, _ := t.Float64()
+		return f
+	case map[string]interface{}:
+		for k, elem := range t {
+			t[k] = fromJSONNumbers(elem)
+		}
+	case []interface{}:
+		for i, elem := range t {
+			t[i] = fromJSONNumbers(elem)
+		}
+	}
+	return v
+}
+
+// preserveFirestoreValues returns newData with the values that are unchanged from oldData
+// replaced by oldData's values. Timestamps, references, bytes and geo points are written to
+// data.json as JSON, so this keeps their Firestore types when they're written back unchanged.
+func preserveFirestoreValues(newData, oldData map[string]interface{}) map[string]interface{} {
+	preserved := make(map[string]interface{}, len(newData))
+	for k, newValue := range newData {
+		preserved[k] = newValue
+		oldValue, ok := oldData[k]
+		if !ok {
+			continue
+		}
+		newMap, newIsMap := newValue.(map[string]interface{})
+		oldMap, oldIsMap := oldValue.(map[string]interface{})
+		if newIsMap && oldIsMap {
+			preserved[k] = preserveFirestoreValues(newMap, oldMap)
+			continue
+		}
+		newJSON, newErr := json.Marshal(newValue)
+		oldJSON, oldErr := json.Marshal(oldValue)
+		if newErr == nil && oldErr == nil && bytes.Equal(newJSON, oldJSON) {
+			preserved[k] = oldValue
+		}
+	}
+	return preserved
+}
+
+type firestoreDocumentModifiedErr struct {
+	path       string
+	updateTime time.Time
+}
+
+func (e firestoreDocumentModifiedErr) Error() string {
+	return fmt.Sprintf(
+		"%v was modified at %v since it was last listed; clear its parent's cache and try again",
+		e.path,
+		e.updateTime,
+	)
+}
+
 func (data *firestoreDocumentDataJSON) Schema() *plugin.EntrySchema {
 	return plugin.NewEntrySchema(data, "data.json").
 		IsSingleton().
@@ -115,4 +255,13 @@ const firestoreDocumentDataJSONDescription = `
 This is a Firestore document's data as pretty-printed JSON. See the
 'firestore' directory's docs for more details on why we have this
 kind of entry.
+
+You can edit it to update the document. Writing a JSON object replaces the
+document's data, or merges into it if the GCP plugin's firestore.merge option
+is set. Writes only succeed if the document hasn't changed since it was last
+listed, so concurrent writers can't clobber each other's changes.
+
+Integers are stored as integers and other numbers as doubles. Timestamps,
+references, bytes and geo points keep their types as long as their JSON
+isn't changed; new ones are stored as strings or maps.
 `
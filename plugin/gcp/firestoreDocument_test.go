@@ -0,0 +1,185 @@
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// These tests run against the Firestore emulator, e.g.
+//
+//	gcloud beta emulators firestore start --host-port=localhost:8080
+//	FIRESTORE_EMULATOR_HOST=localhost:8080 go test ./plugin/gcp
+func newEmulatorClient(t *testing.T) *firestore.Client {
+	if os.Getenv("FIRESTORE_EMULATOR_HOST") == "" {
+		t.Skip("FIRESTORE_EMULATOR_HOST isn't set")
+	}
+	client, err := firestore.NewClient(context.Background(), "wash-test")
+	require.NoError(t, err)
+	return client
+}
+
+// newTestDataJSON creates a document with the given data in a new collection, and returns the
+// collection's path and the document's data.json.
+func newTestDataJSON(t *testing.T, client *firestore.Client, data map[string]interface{}, opts firestoreOptions) (string, *firestoreDocumentDataJSON) {
+	ctx := context.Background()
+	collection := "test-" + uuid.New().String()
+	_, err := client.Collection(collection).Doc("doc").Set(ctx, data)
+	require.NoError(t, err)
+	snapshot, err := client.Collection(collection).Doc("doc").Get(ctx)
+	require.NoError(t, err)
+
+	doc := newFirestoreDocument(client, collection, snapshot, opts)
+	dataJSON, err := newFirestoreDocumentDataJSON(doc.client, doc.path, doc.data, doc.updateTime, opts.merge)
+	require.NoError(t, err)
+	return collection, dataJSON
+}
+
+func readData(t *testing.T, dataJSON *firestoreDocumentDataJSON) map[string]interface{} {
+	content, err := dataJSON.Read(context.Background())
+	require.NoError(t, err)
+	var data map[string]interface{}
+	require.NoError(t, json.Unmarshal(content, &data))
+	return data
+}
+
+func TestFirestoreDocumentDataJSON_Write(t *testing.T) {
+	client := newEmulatorClient(t)
+	defer client.Close()
+	_, dataJSON := newTestDataJSON(t, client, map[string]interface{}{"a": 1, "b": 2}, firestoreOptions{})
+
+	require.NoError(t, dataJSON.Write(context.Background(), []byte(`{"a": 3}`)))
+	assert.Equal(t, map[string]interface{}{"a": float64(3)}, readData(t, dataJSON))
+
+	// Later writes use the new update time.
+	require.NoError(t, dataJSON.Write(context.Background(), []byte(`{"a": 4}`)))
+	assert.Equal(t, map[string]interface{}{"a": float64(4)}, readData(t, dataJSON))
+}
+
+func TestFirestoreDocumentDataJSON_WriteTypes(t *testing.T) {
+	client := newEmulatorClient(t)
+	defer client.Close()
+	ctx := context.Background()
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	collection, dataJSON := newTestDataJSON(t, client, map[string]interface{}{
+		"created": created,
+		"nested":  map[string]interface{}{"created": created},
+	}, firestoreOptions{})
+
+	content, err := dataJSON.Read(ctx)
+	require.NoError(t, err)
+	var data map[string]interface{}
+	require.NoError(t, json.Unmarshal(content, &data))
+	data["int"] = 3
+	data["float"] = 1.5
+	data["list"] = []interface{}{1, 2.5}
+	content, err = json.Marshal(data)
+	require.NoError(t, err)
+	require.NoError(t, dataJSON.Write(ctx, content))
+
+	snapshot, err := client.Collection(collection).Doc("doc").Get(ctx)
+	require.NoError(t, err)
+	stored := snapshot.Data()
+	assert.Equal(t, int64(3), stored["int"])
+	assert.Equal(t, 1.5, stored["float"])
+	assert.Equal(t, []interface{}{int64(1), 2.5}, stored["list"])
+	assert.IsType(t, time.Time{}, stored["created"])
+	assert.IsType(t, time.Time{}, stored["nested"].(map[string]interface{})["created"])
+
+	// The size's updated so that reads through the cached entry aren't truncated.
+	content, err = dataJSON.Read(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(len(content)), dataJSON.Attributes().Size())
+}
+
+func TestFirestoreDocumentDataJSON_WriteMerge(t *testing.T) {
+	client := newEmulatorClient(t)
+	defer client.Close()
+	_, dataJSON := newTestDataJSON(t, client, map[string]interface{}{"a": 1, "b": 2}, firestoreOptions{merge: true})
+
+	require.NoError(t, dataJSON.Write(context.Background(), []byte(`{"a": 3}`)))
+	assert.Equal(t, map[string]interface{}{"a": float64(3), "b": float64(2)}, readData(t, dataJSON))
+}
+
+func TestFirestoreDocumentDataJSON_WriteInvalid(t *testing.T) {
+	client := newEmulatorClient(t)
+	defer client.Close()
+	_, dataJSON := newTestDataJSON(t, client, map[string]interface{}{"a": 1}, firestoreOptions{})
+
+	assert.Error(t, dataJSON.Write(context.Background(), []byte(`[1, 2]`)))
+	assert.Error(t, dataJSON.Write(context.Background(), []byte(`null`)))
+	assert.Error(t, dataJSON.Write(context.Background(), []byte(`{"a":`)))
+}
+
+func TestFirestoreDocumentDataJSON_WriteConflict(t *testing.T) {
+	client := newEmulatorClient(t)
+	defer client.Close()
+	collection, dataJSON := newTestDataJSON(t, client, map[string]interface{}{"a": 1}, firestoreOptions{})
+
+	_, err := client.Collection(collection).Doc("doc").Set(context.Background(), map[string]interface{}{"a": 2})
+	require.NoError(t, err)
+
+	err = dataJSON.Write(context.Background(), []byte(`{"a": 3}`))
+	assert.IsType(t, firestoreDocumentModifiedErr{}, err)
+}
+
+func TestFirestoreQueryDir(t *testing.T) {
+	client := newEmulatorClient(t)
+	defer client.Close()
+	ctx := context.Background()
+
+	collection := "test-" + uuid.New().String()
+	for id, age := range map[string]int{"alice": 30, "bob": 15, "carol": 40} {
+		_, err := client.Collection(collection).Doc(id).Set(ctx, map[string]interface{}{"age": age})
+		require.NoError(t, err)
+	}
+
+	query := firestoreQuery{
+		name:       "adults",
+		collection: collection,
+		where:      []firestoreWhere{{field: "age", op: ">=", value: 18}},
+		orderBy:    []firestoreOrder{{field: "age", direction: firestore.Desc}},
+	}
+	entries, err := newFirestoreQueryDir(client, query, firestoreOptions{}).List(ctx)
+	require.NoError(t, err)
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, plugin.Name(entry))
+	}
+	assert.Equal(t, []string{"carol", "alice"}, names)
+}
+
+func TestPreserveFirestoreValues(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	oldData := map[string]interface{}{
+		"created": created,
+		"updated": created,
+		"nested":  map[string]interface{}{"created": created},
+	}
+	newData := fromJSONNumbers(map[string]interface{}{
+		"created": "2020-01-02T03:04:05Z",
+		"updated": "2021-01-02T03:04:05Z",
+		"nested":  map[string]interface{}{"created": "2020-01-02T03:04:05Z"},
+		"int":     json.Number("3"),
+		"float":   json.Number("1.5"),
+		"list":    []interface{}{json.Number("1"), json.Number("2.5")},
+	}).(map[string]interface{})
+
+	assert.Equal(t, map[string]interface{}{
+		"created": created,
+		"updated": "2021-01-02T03:04:05Z",
+		"nested":  map[string]interface{}{"created": created},
+		"int":     int64(3),
+		"float":   1.5,
+		"list":    []interface{}{int64(1), 2.5},
+	}, preserveFirestoreValues(newData, oldData))
+}
@@ -0,0 +1,262 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/firestore"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// firestoreQuery is a query that's saved in Wash's config file.
+type firestoreQuery struct {
+	name       string
+	collection string
+	where      []firestoreWhere
+	orderBy    []firestoreOrder
+	limit      int
+}
+
+type firestoreWhere struct {
+	field string
+	op    string
+	value interface{}
+}
+
+type firestoreOrder struct {
+	field     string
+	direction firestore.Direction
+}
+
+func (q firestoreQuery) query(client *firestore.Client) firestore.Query {
+	query := client.Collection(q.collection).Query
+	for _, w := range q.where {
+		query = query.Where(w.field, w.op, w.value)
+	}
+	for _, o := range q.orderBy {
+		query = query.OrderBy(o.field, o.direction)
+	}
+	if q.limit > 0 {
+		query = query.Limit(q.limit)
+	}
+	return query
+}
+
+var firestoreOps = []string{"<", "<=", ">", ">=", "==", "array-contains", "in", "array-contains-any"}
+
+// parseFirestoreQueries parses the gcp.firestore.queries config, which maps each query's name
+// to its collection, where clauses, order and limit.
+func parseFirestoreQueries(queriesI interface{}) ([]firestoreQuery, error) {
+	queriesCfg, ok := toStringMap(queriesI)
+	if !ok {
+		return nil, fmt.Errorf("gcp.firestore.queries config must be an object, not %v", queriesI)
+	}
+	var queries []firestoreQuery
+	for name, queryI := range queriesCfg {
+		query, err := parseFirestoreQuery(name, queryI)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, query)
+	}
+	// Sort the queries so that their order doesn't depend on map iteration.
+	sort.Slice(queries, func(i, j int) bool { return queries[i].name < queries[j].name })
+	return queries, nil
+}
+
+func parseFirestoreQuery(name string, queryI interface{}) (firestoreQuery, error) {
+	query := firestoreQuery{name: name}
+	key := "gcp.firestore.queries." + name
+	queryCfg, ok := toStringMap(queryI)
+	if !ok {
+		return query, fmt.Errorf("%v config must be an object, not %v", key, queryI)
+	}
+
+	if query.collection, ok = queryCfg["collection"].(string); !ok || query.collection == "" {
+		return query, fmt.Errorf("%v.collection config must be a non-empty string, not %v", key, queryCfg["collection"])
+	}
+
+	if whereI, ok := queryCfg["where"]; ok {
+		clauses, ok := whereI.([]interface{})
+		if !ok {
+			return query, fmt.Errorf("%v.where config must be an array of objects, not %v", key, whereI)
+		}
+		for _, clauseI := range clauses {
+			clause, ok := toStringMap(clauseI)
+			if !ok {
+				return query, fmt.Errorf("%v.where config must be an array of objects, not %v", key, whereI)
+			}
+			where := firestoreWhere{value: clause["value"]}
+			if where.field, ok = clause["field"].(string); !ok || where.field == "" {
+				return query, fmt.Errorf("%v.where field must be a non-empty string, not %v", key, clause["field"])
+			}
+			where.op, _ = clause["op"].(string)
+			if !containsString(firestoreOps, where.op) {
+				return query, fmt.Errorf("%v.where op must be one of %v, not %v", key, strings.Join(firestoreOps, ", "), clause["op"])
+			}
+			query.where = append(query.where, where)
+		}
+	}
+
+	if orderI, ok := queryCfg["order_by"]; ok {
+		orders, ok := orderI.([]interface{})
+		if !ok {
+			return query, fmt.Errorf("%v.order_by config must be an array of strings, not %v", key, orderI)
+		}
+		for _, orderI := range orders {
+			order, ok := orderI.(string)
+			if !ok {
+				return query, fmt.Errorf("%v.order_by config must be an array of strings, not %v", key, orders)
+			}
+			o, err := parseFirestoreOrder(order)
+			if err != nil {
+				return query, fmt.Errorf("%v.order_by config is invalid: %v", key, err)
+			}
+			query.orderBy = append(query.orderBy, o)
+		}
+	}
+
+	if limitI, ok := queryCfg["limit"]; ok {
+		if query.limit, ok = limitI.(int); !ok || query.limit <= 0 {
+			return query, fmt.Errorf("%v.limit config must be a positive integer, not %v", key, limitI)
+		}
+	}
+	return query, nil
+}
+
+// parseFirestoreOrder parses "<field> [asc|desc]".
+func parseFirestoreOrder(order string) (firestoreOrder, error) {
+	segments := strings.Fields(order)
+	if len(segments) == 0 || len(segments) > 2 {
+		return firestoreOrder{}, fmt.Errorf("expected '<field> [asc|desc]', not %q", order)
+	}
+	o := firestoreOrder{field: segments[0], direction: firestore.Asc}
+	if len(segments) == 2 {
+		switch strings.ToLower(segments[1]) {
+		case "asc":
+		case "desc":
+			o.direction = firestore.Desc
+		default:
+			return o, fmt.Errorf("expected '<field> [asc|desc]', not %q", order)
+		}
+	}
+	return o, nil
+}
+
+// toStringMap converts a config object to a map. Objects nested in arrays aren't normalized by
+// the config loader, so they may have interface{} keys.
+func toStringMap(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		strMap := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			key, ok := k.(string)
+			if !ok {
+				return nil, false
+			}
+			strMap[key] = v
+		}
+		return strMap, true
+	default:
+		return nil, false
+	}
+}
+
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+const firestoreQueriesDirName = "queries"
+
+// firestoreQueriesDir contains the saved queries.
+type firestoreQueriesDir struct {
+	plugin.EntryBase
+	client *firestore.Client
+	opts   firestoreOptions
+}
+
+func newFirestoreQueriesDir(client *firestore.Client, opts firestoreOptions) *firestoreQueriesDir {
+	return &firestoreQueriesDir{
+		EntryBase: plugin.NewEntry(firestoreQueriesDirName),
+		client:    client,
+		opts:      opts,
+	}
+}
+
+func (d *firestoreQueriesDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	entries := make([]plugin.Entry, len(d.opts.queries))
+	for i, query := range d.opts.queries {
+		entries[i] = newFirestoreQueryDir(d.client, query, d.opts)
+	}
+	return entries, nil
+}
+
+func (d *firestoreQueriesDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(d, firestoreQueriesDirName).
+		IsSingleton().
+		SetDescription(firestoreQueriesDirDescription)
+}
+
+func (d *firestoreQueriesDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&firestoreQueryDir{}).Schema(),
+	}
+}
+
+// firestoreQueryDir contains the documents that match a saved query.
+type firestoreQueryDir struct {
+	plugin.EntryBase
+	client *firestore.Client
+	query  firestoreQuery
+	opts   firestoreOptions
+}
+
+func newFirestoreQueryDir(client *firestore.Client, query firestoreQuery, opts firestoreOptions) *firestoreQueryDir {
+	return &firestoreQueryDir{
+		EntryBase: plugin.NewEntry(query.name),
+		client:    client,
+		query:     query,
+		opts:      opts,
+	}
+}
+
+func (d *firestoreQueryDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	docs, err := d.query.query(d.client).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]plugin.Entry, len(docs))
+	for ix, doc := range docs {
+		entries[ix] = newFirestoreDocument(d.client, d.query.collection, doc, d.opts)
+	}
+	return entries, nil
+}
+
+func (d *firestoreQueryDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(d, "query").
+		SetDescription(firestoreQueryDirDescription)
+}
+
+func (d *firestoreQueryDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&firestoreDocument{}).Schema(),
+	}
+}
+
+const firestoreQueriesDirDescription = `
+This directory contains the Firestore queries that are saved in Wash's config
+file. See the 'firestore' directory's docs for how to save queries.
+`
+
+const firestoreQueryDirDescription = `
+This directory contains the documents that match a saved Firestore query.
+`
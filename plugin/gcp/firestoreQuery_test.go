@@ -0,0 +1,64 @@
+package gcp
+
+import (
+	"testing"
+
+	"cloud.google.com/go/firestore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFirestoreQueries(t *testing.T) {
+	queries, err := parseFirestoreQueries(map[string]interface{}{
+		"adults": map[string]interface{}{
+			"collection": "users",
+			// Objects in arrays aren't normalized by the config loader.
+			"where": []interface{}{
+				map[interface{}]interface{}{"field": "age", "op": ">=", "value": 18},
+			},
+			"order_by": []interface{}{"age desc", "name"},
+			"limit":    10,
+		},
+		"all": map[string]interface{}{"collection": "users"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []firestoreQuery{
+		{name: "adults", collection: "users",
+			where:   []firestoreWhere{{field: "age", op: ">=", value: 18}},
+			orderBy: []firestoreOrder{{field: "age", direction: firestore.Desc}, {field: "name", direction: firestore.Asc}},
+			limit:   10,
+		},
+		{name: "all", collection: "users"},
+	}, queries)
+}
+
+func TestParseFirestoreQueries_Invalid(t *testing.T) {
+	invalid := map[string]map[string]interface{}{
+		"gcp.firestore.queries.q.collection config must be a non-empty string": {},
+		"gcp.firestore.queries.q.where op must be one of": {
+			"collection": "users",
+			"where":      []interface{}{map[string]interface{}{"field": "age", "op": "!=", "value": 18}},
+		},
+		"gcp.firestore.queries.q.where field must be a non-empty string": {
+			"collection": "users",
+			"where":      []interface{}{map[string]interface{}{"op": "==", "value": 18}},
+		},
+		"gcp.firestore.queries.q.order_by config is invalid": {
+			"collection": "users",
+			"order_by":   []interface{}{"age sideways"},
+		},
+		"gcp.firestore.queries.q.limit config must be a positive integer": {
+			"collection": "users",
+			"limit":      -1,
+		},
+	}
+	for msg, cfg := range invalid {
+		_, err := parseFirestoreQueries(map[string]interface{}{"q": cfg})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), msg)
+		}
+	}
+
+	_, err := parseFirestoreQueries([]interface{}{"q"})
+	assert.EqualError(t, err, "gcp.firestore.queries config must be an object, not [q]")
+}
@@ -0,0 +1,108 @@
+package gcp
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/plugin/kubernetes"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/container/v1"
+	"k8s.io/client-go/rest"
+)
+
+type gkeCluster struct {
+	plugin.EntryBase
+	cluster *container.Cluster
+	service gkeProjectService
+	opts    kubernetes.Options
+	// k8context is the cluster's Kubernetes context. It's created on the first List so
+	// that its clientset is reused by later ones.
+	k8contextOnce sync.Once
+	k8context     plugin.Parent
+	k8contextErr  error
+}
+
+func newGKECluster(name string, cluster *container.Cluster, service gkeProjectService, opts kubernetes.Options) *gkeCluster {
+	c := &gkeCluster{
+		EntryBase: plugin.NewEntry(name),
+		cluster:   cluster,
+		service:   service,
+		opts:      opts,
+	}
+	c.SetPartialMetadata(cluster)
+	if crtime, err := time.Parse(time.RFC3339, cluster.CreateTime); err == nil {
+		c.Attributes().SetCrtime(crtime)
+	}
+	return c
+}
+
+// restConfig returns the config for connecting to the cluster's Kubernetes API. It
+// authenticates with the GCP credentials' OAuth token, so no kubeconfig's needed.
+func (c *gkeCluster) restConfig() (*rest.Config, error) {
+	if c.cluster.Endpoint == "" {
+		return nil, fmt.Errorf("cluster %v has no endpoint; its status is %v", c.cluster.Name, c.cluster.Status)
+	}
+	var caData []byte
+	if c.cluster.MasterAuth != nil {
+		var err error
+		caData, err = base64.StdEncoding.DecodeString(c.cluster.MasterAuth.ClusterCaCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode the CA certificate of cluster %v: %v", c.cluster.Name, err)
+		}
+	}
+
+	tokenSource := c.service.tokenSource
+	return &rest.Config{
+		Host:            "https://" + c.cluster.Endpoint,
+		TLSClientConfig: rest.TLSClientConfig{CAData: caData},
+		WrapTransport: func(rt http.RoundTripper) http.RoundTripper {
+			return &oauth2.Transport{Source: tokenSource, Base: rt}
+		},
+	}, nil
+}
+
+// List lists the cluster's namespaces with the same hierarchy as the Kubernetes plugin.
+func (c *gkeCluster) List(ctx context.Context) ([]plugin.Entry, error) {
+	c.k8contextOnce.Do(func() {
+		var config *rest.Config
+		config, c.k8contextErr = c.restConfig()
+		if c.k8contextErr != nil {
+			return
+		}
+		c.k8context, c.k8contextErr = kubernetes.NewContext(c.Name(), config, c.opts)
+	})
+	if c.k8contextErr != nil {
+		return nil, c.k8contextErr
+	}
+	return c.k8context.List(ctx)
+}
+
+func (c *gkeCluster) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(c, "cluster").
+		SetDescription(gkeClusterDescription).
+		SetPartialMetadataSchema(container.Cluster{})
+}
+
+func (c *gkeCluster) ChildSchemas() []*plugin.EntrySchema {
+	return kubernetes.ContextChildSchemas()
+}
+
+const gkeClusterDescription = `
+This is a GKE cluster. It contains the cluster's namespaces, which have the same
+hierarchy as the Kubernetes plugin's namespaces. Wash connects to the cluster's
+endpoint with your GCP credentials, so you don't need a kubeconfig entry for it.
+Your credentials need Kubernetes RBAC permissions in the cluster. The cluster's
+entries take the same options as the Kubernetes plugin's entries, which you can
+set with something like
+
+gcp:
+  gke:
+    log_since: 1h
+
+in Wash's config file.
+`
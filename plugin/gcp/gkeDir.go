@@ -0,0 +1,83 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/plugin/kubernetes"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/container/v1"
+	"google.golang.org/api/option"
+)
+
+type gkeProjectService struct {
+	*container.Service
+	projectID string
+	// We need to pass this around to authenticate with the clusters' Kubernetes APIs
+	tokenSource oauth2.TokenSource
+}
+
+type gkeDir struct {
+	plugin.EntryBase
+	service gkeProjectService
+	opts    kubernetes.Options
+}
+
+func newGKEDir(ctx context.Context, client *http.Client, tokenSource oauth2.TokenSource, projID string, opts kubernetes.Options) (*gkeDir, error) {
+	svc, err := container.NewService(context.Background(), option.WithHTTPClient(client))
+	if err != nil {
+		return nil, err
+	}
+	gke := &gkeDir{
+		EntryBase: plugin.NewEntry("gke"),
+		service:   gkeProjectService{Service: svc, projectID: projID, tokenSource: tokenSource},
+		opts:      opts,
+	}
+	if _, err := plugin.List(ctx, gke); err != nil {
+		gke.MarkInaccessible(ctx, err)
+	}
+	return gke, nil
+}
+
+func (g *gkeDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	parent := fmt.Sprintf("projects/%s/locations/-", g.service.projectID)
+	resp, err := g.service.Projects.Locations.Clusters.List(parent).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	// Cluster names are only unique within a location, so include the location in the
+	// names of clusters that share a name.
+	counts := make(map[string]int)
+	for _, cluster := range resp.Clusters {
+		counts[cluster.Name]++
+	}
+	entries := make([]plugin.Entry, len(resp.Clusters))
+	for i, cluster := range resp.Clusters {
+		name := cluster.Name
+		if counts[name] > 1 {
+			name = cluster.Name + "@" + cluster.Location
+		}
+		entries[i] = newGKECluster(name, cluster, g.service, g.opts)
+	}
+	return entries, nil
+}
+
+func (g *gkeDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(g, "gke").
+		SetDescription(gkeDirDescription).
+		IsSingleton()
+}
+
+func (g *gkeDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&gkeCluster{}).Schema(),
+	}
+}
+
+const gkeDirDescription = `
+This directory contains the project's GKE clusters across all locations. Clusters
+that share a name are named <name>@<location>.
+`
@@ -8,23 +8,26 @@ import (
 	"sync"
 
 	"github.com/puppetlabs/wash/plugin"
+	"golang.org/x/oauth2"
 	crm "google.golang.org/api/cloudresourcemanager/v1"
 	"google.golang.org/api/option"
 )
 
 type project struct {
 	plugin.EntryBase
-	client *http.Client
-	id     string
+	client      *http.Client
+	tokenSource oauth2.TokenSource
+	id          string
+	opts        options
 }
 
 // NewProject creates a new project with a collection of service clients.
-func newProject(p *crm.Project, client *http.Client) *project {
+func newProject(p *crm.Project, client *http.Client, tokenSource oauth2.TokenSource, opts options) *project {
 	name := p.Name
 	if name == "" {
 		name = p.ProjectId
 	}
-	proj := &project{EntryBase: plugin.NewEntry(name), client: client, id: p.ProjectId}
+	proj := &project{EntryBase: plugin.NewEntry(name), client: client, tokenSource: tokenSource, id: p.ProjectId, opts: opts}
 	proj.SetPartialMetadata(p)
 	return proj
 }
@@ -49,11 +52,15 @@ func (p *project) List(ctx context.Context) ([]plugin.Entry, error) {
 
 	go func() { save(newComputeDir(ctx, p.client, p.id)) }()
 	go func() { save(newStorageDir(ctx, p.client, p.id)) }()
-	go func() { save(newFirestoreDir(ctx, p.id)) }()
-	go func() { save(newPubsubDir(ctx, p.id)) }()
+	go func() { save(newFirestoreDir(ctx, p.id, p.opts.firestore)) }()
+	go func() { save(newPubsubDir(ctx, p.id, p.opts.pubsub)) }()
 	go func() { save(newCloudFunctionsDir(ctx, p.client, p.id)) }()
 	go func() { save(newCloudRunDir(ctx, p.client, p.id)) }()
-	wg.Add(6)
+	go func() { save(newGKEDir(ctx, p.client, p.tokenSource, p.id, p.opts.gke)) }()
+	go func() { save(newCloudSQLDir(ctx, p.client, p.id)) }()
+	go func() { save(newBigqueryDir(ctx, p.client, p.id)) }()
+	go func() { save(newSecretManagerDir(ctx, p.client, p.id)) }()
+	wg.Add(10)
 	wg.Wait()
 
 	if len(errs) > 0 {
@@ -86,6 +93,10 @@ func (p *project) ChildSchemas() []*plugin.EntrySchema {
 		(&pubsubDir{}).Schema(),
 		(&cloudFunctionsDir{}).Schema(),
 		(&cloudRunDir{}).Schema(),
+		(&gkeDir{}).Schema(),
+		(&cloudSQLDir{}).Schema(),
+		(&bigqueryDir{}).Schema(),
+		(&secretManagerDir{}).Schema(),
 	}
 }
 
@@ -3,24 +3,37 @@ package gcp
 import (
 	"context"
 
+	monitoring "cloud.google.com/go/monitoring/apiv3"
 	"cloud.google.com/go/pubsub"
+	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/plugin"
 	"google.golang.org/api/iterator"
 )
 
 type pubsubDir struct {
 	plugin.EntryBase
-	client *pubsub.Client
+	client    *pubsub.Client
+	metrics   *monitoring.MetricClient
+	projectID string
+	opts      pubsubOptions
 }
 
-func newPubsubDir(ctx context.Context, projID string) (*pubsubDir, error) {
-	cli, err := pubsub.NewClient(context.Background(), projID)
+func newPubsubDir(ctx context.Context, projID string, opts pubsubOptions) (*pubsubDir, error) {
+	clientContext := context.Background()
+	cli, err := pubsub.NewClient(clientContext, projID)
 	if err != nil {
 		return nil, err
 	}
+	metrics, err := monitoring.NewMetricClient(clientContext)
+	if err != nil {
+		activity.Record(ctx, "Unable to create metrics client for %v/pubsub: %v", projID, err)
+	}
 	p := &pubsubDir{
 		EntryBase: plugin.NewEntry("pubsub"),
 		client:    cli,
+		metrics:   metrics,
+		projectID: projID,
+		opts:      opts,
 	}
 	if _, err := plugin.List(ctx, p); err != nil {
 		p.MarkInaccessible(ctx, err)
@@ -28,7 +41,7 @@ func newPubsubDir(ctx context.Context, projID string) (*pubsubDir, error) {
 	return p, nil
 }
 
-// List all topics as dirs
+// List all topics as files, and a directory of their subscriptions.
 func (p *pubsubDir) List(ctx context.Context) ([]plugin.Entry, error) {
 	topics := make([]plugin.Entry, 0)
 	it := p.client.Topics(ctx)
@@ -42,7 +55,15 @@ func (p *pubsubDir) List(ctx context.Context) ([]plugin.Entry, error) {
 		}
 		topics = append(topics, newPubsubTopic(p.client, t))
 	}
-	return topics, nil
+
+	// Don't hide a topic that has the same name as the subscriptions directory.
+	for _, topic := range topics {
+		if plugin.Name(topic) == pubsubSubscriptionsDirName {
+			activity.Record(ctx, "Omitting subscriptions of %v because it has a %v topic", p.projectID, pubsubSubscriptionsDirName)
+			return topics, nil
+		}
+	}
+	return append(topics, newPubsubSubscriptionsDir(p)), nil
 }
 
 func (p *pubsubDir) Schema() *plugin.EntrySchema {
@@ -54,11 +75,13 @@ func (p *pubsubDir) Schema() *plugin.EntrySchema {
 func (p *pubsubDir) ChildSchemas() []*plugin.EntrySchema {
 	return []*plugin.EntrySchema{
 		(&pubsubTopic{}).Schema(),
+		(&pubsubSubscriptionsDir{}).Schema(),
 	}
 }
 
 const pubsubDirDescription = `
-This directory represents Cloud Pub/Sub. Its entries consist of Pub/Sub topics.
+This directory represents Cloud Pub/Sub. Its entries consist of Pub/Sub topics
+and a subscriptions directory.
 
 You can publish a message to a topic by appending text to the topic file. For example
 		wash gcp/project/pubsub > tail -f topic &
@@ -0,0 +1,222 @@
+package gcp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	"google.golang.org/genproto/googleapis/monitoring/v3"
+)
+
+type pubsubSubscription struct {
+	plugin.EntryBase
+	pubsub *pubsubDir
+	sub    *pubsub.Subscription
+}
+
+type pubsubSubscriptionMetadata struct {
+	Topic               string
+	PushEndpoint        string
+	AckDeadline         time.Duration
+	RetainAckedMessages bool
+	RetentionDuration   time.Duration
+	Labels              map[string]string
+	DeadLetterPolicy    *pubsub.DeadLetterPolicy
+	// Backlog is the number of unacknowledged messages, as reported by Stackdriver.
+	Backlog int64
+}
+
+func newPubsubSubscription(pubsub *pubsubDir, sub *pubsub.Subscription) *pubsubSubscription {
+	return &pubsubSubscription{
+		EntryBase: plugin.NewEntry(sub.ID()),
+		pubsub:    pubsub,
+		sub:       sub,
+	}
+}
+
+func (s *pubsubSubscription) Metadata(ctx context.Context) (plugin.JSONObject, error) {
+	cfg, err := s.sub.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := pubsubSubscriptionMetadata{
+		PushEndpoint:        cfg.PushConfig.Endpoint,
+		AckDeadline:         cfg.AckDeadline,
+		RetainAckedMessages: cfg.RetainAckedMessages,
+		RetentionDuration:   cfg.RetentionDuration,
+		Labels:              cfg.Labels,
+		DeadLetterPolicy:    cfg.DeadLetterPolicy,
+		Backlog:             s.backlog(ctx),
+	}
+	if cfg.Topic != nil {
+		meta.Topic = cfg.Topic.ID()
+	}
+	return plugin.ToJSONObject(meta), nil
+}
+
+// backlog returns the subscription's number of undelivered messages. Pub/Sub only reports it
+// to Stackdriver, so it's 0 if Stackdriver's unavailable (e.g. with the Pub/Sub emulator).
+func (s *pubsubSubscription) backlog(ctx context.Context) int64 {
+	if s.pubsub.metrics == nil {
+		return 0
+	}
+	now := time.Now()
+	req := &monitoring.ListTimeSeriesRequest{
+		Name:   "projects/" + s.pubsub.projectID,
+		Filter: `metric.type = "pubsub.googleapis.com/subscription/num_undelivered_messages" AND resource.label.subscription_id = "` + s.Name() + `"`,
+		Interval: &monitoring.TimeInterval{
+			StartTime: &timestamp.Timestamp{Seconds: now.Add(-10 * time.Minute).Unix()},
+			EndTime:   &timestamp.Timestamp{Seconds: now.Unix()},
+		},
+		PageSize: 1,
+	}
+	series, err := s.pubsub.metrics.ListTimeSeries(ctx, req).Next()
+	if err != nil {
+		activity.Record(ctx, "Unable to get backlog of subscription %v from Stackdriver: %v", s.Name(), err)
+		return 0
+	}
+	if len(series.Points) <= 0 {
+		return 0
+	}
+	return series.Points[0].Value.GetInt64Value()
+}
+
+func (s *pubsubSubscription) Signal(ctx context.Context, signal string) error {
+	if !strings.HasPrefix(signal, "seek:") {
+		return fmt.Errorf("unsupported signal %v", signal)
+	}
+	target := strings.TrimPrefix(signal, "seek:")
+	if strings.HasPrefix(target, "snapshot/") {
+		return s.sub.SeekToSnapshot(ctx, s.pubsub.client.Snapshot(strings.TrimPrefix(target, "snapshot/")))
+	}
+	t, err := parseSeekTime(target, time.Now())
+	if err != nil {
+		return err
+	}
+	return s.sub.SeekToTime(ctx, t)
+}
+
+// parseSeekTime parses an RFC3339 timestamp or a duration before now. Signals are lowercased,
+// so the timestamp's uppercased before it's parsed.
+func parseSeekTime(target string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(target)); err == nil {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(target); err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("seek target %v must be an RFC3339 timestamp, a duration or snapshot/<name>", target)
+}
+
+func (s *pubsubSubscription) Stream(ctx context.Context) (io.ReadCloser, error) {
+	// A subscription can only receive once at a time, so use a new one for each stream.
+	sub := s.pubsub.client.Subscription(s.sub.ID())
+	return newPubsubSubscriptionReader(ctx, sub, s.pubsub.opts.ack), nil
+}
+
+func (s *pubsubSubscription) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(s, "subscription").
+		SetMetadataSchema(pubsubSubscriptionMetadata{}).
+		SetDescription(pubsubSubscriptionDescription).
+		AddSignalGroup(
+			"seek",
+			`\Aseek:.+`,
+			"Seeks the subscription to a time or snapshot. The signal is seek:<target>, where\n"+
+				"<target> is an RFC3339 timestamp, a duration before now like 1h, or\n"+
+				"snapshot/<name>.",
+		)
+}
+
+// pubsubSubscriptionReader is a ReadCloser that pulls messages from a subscription.
+type pubsubSubscriptionReader struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	queue  <-chan *pubsub.Message
+	buf    bytes.Buffer
+	// done is closed when the subscription stops receiving messages. err is set before then.
+	done chan struct{}
+	err  error
+}
+
+func newPubsubSubscriptionReader(ctx context.Context, sub *pubsub.Subscription, ack bool) *pubsubSubscriptionReader {
+	ctx, cancel := context.WithCancel(ctx)
+	queue := make(chan *pubsub.Message)
+	rdr := &pubsubSubscriptionReader{ctx: ctx, cancel: cancel, queue: queue, done: make(chan struct{})}
+
+	receive := func(ctx context.Context, msg *pubsub.Message) {
+		select {
+		case queue <- msg:
+		case <-ctx.Done():
+			msg.Nack()
+			return
+		}
+		if ack {
+			msg.Ack()
+			return
+		}
+		// Hold the message until the stream's closed so that it isn't redelivered to this
+		// stream, then make it available to other subscribers.
+		<-ctx.Done()
+		msg.Nack()
+	}
+	go func() {
+		defer close(rdr.done)
+		rdr.err = sub.Receive(ctx, receive)
+	}()
+	return rdr
+}
+
+func (r *pubsubSubscriptionReader) Read(p []byte) (int, error) {
+	if r.buf.Len() > 0 {
+		return r.buf.Read(p)
+	}
+
+	// Wait for an outstanding message, context completion, or error.
+	select {
+	case <-r.ctx.Done():
+		return 0, io.EOF
+	case msg := <-r.queue:
+		activity.Record(r.ctx, "Reading next message: %v", msg)
+		fmt.Fprintf(&r.buf, "%v | %v\n", msg.PublishTime.Format(time.StampMilli), string(msg.Data))
+		return r.buf.Read(p)
+	case <-r.done:
+		if r.err != nil {
+			return 0, r.err
+		}
+		return 0, io.EOF
+	}
+}
+
+// Close stops pulling messages. It waits for unacknowledged messages to be released so that
+// they're immediately available to other subscribers.
+func (r *pubsubSubscriptionReader) Close() error {
+	r.cancel()
+	<-r.done
+	return nil
+}
+
+const pubsubSubscriptionDescription = `
+A Cloud Pub/Sub subscription. Its metadata includes the subscription's ack
+deadline, dead-letter policy and backlog.
+
+You can stream messages from it with 'tail -f'. Streaming doesn't acknowledge
+the messages, so they're redelivered after the stream's closed; see the GCP
+plugin's docs to acknowledge them instead. The stream stops receiving messages
+after 1000 unacknowledged messages.
+
+You can seek the subscription with the seek signal, e.g.
+
+  signal seek:1h subscription
+  signal seek:2020-06-01t00:00:00z subscription
+  signal seek:snapshot/my-snapshot subscription
+
+Signals are lowercased, so the snapshot's name must be lowercase.
+`
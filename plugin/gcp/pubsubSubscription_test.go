@@ -0,0 +1,120 @@
+package gcp
+
+import (
+	"bufio"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+)
+
+// newTestSubscription creates a topic and subscription on a fake Pub/Sub server.
+func newTestSubscription(t *testing.T, opts pubsubOptions) (*pubsubSubscription, *pubsub.Topic, func()) {
+	ctx := context.Background()
+	srv := pstest.NewServer()
+	conn, err := grpc.Dial(srv.Addr, grpc.WithInsecure())
+	require.NoError(t, err)
+	client, err := pubsub.NewClient(ctx, "proj", option.WithGRPCConn(conn))
+	require.NoError(t, err)
+
+	topic, err := client.CreateTopic(ctx, "topic")
+	require.NoError(t, err)
+	sub, err := client.CreateSubscription(ctx, "sub", pubsub.SubscriptionConfig{
+		Topic:       topic,
+		AckDeadline: 10 * time.Second,
+	})
+	require.NoError(t, err)
+
+	dir := &pubsubDir{client: client, projectID: "proj", opts: opts}
+	return newPubsubSubscription(dir, sub), topic, func() {
+		topic.Stop()
+		client.Close()
+		conn.Close()
+		srv.Close()
+	}
+}
+
+func publish(t *testing.T, topic *pubsub.Topic, msg string) {
+	_, err := topic.Publish(context.Background(), &pubsub.Message{Data: []byte(msg)}).Get(context.Background())
+	require.NoError(t, err)
+}
+
+// readMessage streams the subscription until it receives a message, then closes the stream.
+func readMessage(t *testing.T, s *pubsubSubscription) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	rdr, err := s.Stream(ctx)
+	require.NoError(t, err)
+	defer rdr.Close()
+
+	line, err := bufio.NewReader(rdr).ReadString('\n')
+	require.NoError(t, err)
+	return line
+}
+
+func TestPubsubSubscription_Metadata(t *testing.T) {
+	s, _, cleanup := newTestSubscription(t, pubsubOptions{})
+	defer cleanup()
+
+	meta, err := s.Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "topic", meta["Topic"])
+	assert.Equal(t, float64(10*time.Second), meta["AckDeadline"])
+}
+
+func TestPubsubSubscription_StreamDoesNotAck(t *testing.T) {
+	s, topic, cleanup := newTestSubscription(t, pubsubOptions{})
+	defer cleanup()
+
+	publish(t, topic, "hello")
+	assert.Regexp(t, `\| hello\n$`, readMessage(t, s))
+	// The message wasn't acknowledged, so it's redelivered.
+	assert.Regexp(t, `\| hello\n$`, readMessage(t, s))
+}
+
+func TestPubsubSubscription_StreamAck(t *testing.T) {
+	s, topic, cleanup := newTestSubscription(t, pubsubOptions{ack: true})
+	defer cleanup()
+
+	publish(t, topic, "hello")
+	assert.Regexp(t, `\| hello\n$`, readMessage(t, s))
+	publish(t, topic, "world")
+	assert.Regexp(t, `\| world\n$`, readMessage(t, s))
+}
+
+func TestPubsubSubscription_Seek(t *testing.T) {
+	s, topic, cleanup := newTestSubscription(t, pubsubOptions{})
+	defer cleanup()
+
+	// Seeking past a message acknowledges it. The fake server doesn't support seeking back to
+	// messages that were already acknowledged.
+	publish(t, topic, "hello")
+	future := time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+	require.NoError(t, s.Signal(context.Background(), "seek:"+strings.ToLower(future)))
+	publish(t, topic, "world")
+	assert.Regexp(t, `\| world\n$`, readMessage(t, s))
+
+	assert.Error(t, s.Signal(context.Background(), "seek:yesterday"))
+}
+
+func TestParseSeekTime(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	target, err := parseSeekTime("2020-06-01t10:30:00z", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 6, 1, 10, 30, 0, 0, time.UTC), target)
+
+	target, err = parseSeekTime("90m", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 6, 1, 10, 30, 0, 0, time.UTC), target)
+
+	_, err = parseSeekTime("yesterday", now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,103 @@
+package gcp
+
+import (
+	"context"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/puppetlabs/wash/plugin"
+	"google.golang.org/api/iterator"
+)
+
+const pubsubSubscriptionsDirName = "subscriptions"
+
+// pubsubSubscriptionsDir groups the project's subscriptions by their topic.
+type pubsubSubscriptionsDir struct {
+	plugin.EntryBase
+	pubsub *pubsubDir
+}
+
+func newPubsubSubscriptionsDir(pubsub *pubsubDir) *pubsubSubscriptionsDir {
+	return &pubsubSubscriptionsDir{
+		EntryBase: plugin.NewEntry(pubsubSubscriptionsDirName),
+		pubsub:    pubsub,
+	}
+}
+
+func (d *pubsubSubscriptionsDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var entries []plugin.Entry
+	it := d.pubsub.client.Topics(ctx)
+	for {
+		t, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, newPubsubTopicSubscriptions(d.pubsub, t))
+	}
+	return entries, nil
+}
+
+func (d *pubsubSubscriptionsDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(d, pubsubSubscriptionsDirName).
+		IsSingleton().
+		SetDescription(pubsubSubscriptionsDirDescription)
+}
+
+func (d *pubsubSubscriptionsDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&pubsubTopicSubscriptions{}).Schema(),
+	}
+}
+
+// pubsubTopicSubscriptions contains a topic's subscriptions.
+type pubsubTopicSubscriptions struct {
+	plugin.EntryBase
+	pubsub *pubsubDir
+	topic  *pubsub.Topic
+}
+
+func newPubsubTopicSubscriptions(pubsub *pubsubDir, topic *pubsub.Topic) *pubsubTopicSubscriptions {
+	return &pubsubTopicSubscriptions{
+		EntryBase: plugin.NewEntry(topic.ID()),
+		pubsub:    pubsub,
+		topic:     topic,
+	}
+}
+
+func (d *pubsubTopicSubscriptions) List(ctx context.Context) ([]plugin.Entry, error) {
+	var entries []plugin.Entry
+	it := d.topic.Subscriptions(ctx)
+	for {
+		s, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, newPubsubSubscription(d.pubsub, s))
+	}
+	return entries, nil
+}
+
+func (d *pubsubTopicSubscriptions) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(d, "topic_subscriptions").
+		SetDescription(pubsubTopicSubscriptionsDescription)
+}
+
+func (d *pubsubTopicSubscriptions) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&pubsubSubscription{}).Schema(),
+	}
+}
+
+const pubsubSubscriptionsDirDescription = `
+This directory contains the project's Pub/Sub subscriptions, grouped by topic.
+Subscriptions to deleted topics aren't included.
+`
+
+const pubsubTopicSubscriptionsDescription = `
+This directory contains a topic's subscriptions.
+`
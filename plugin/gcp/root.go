@@ -9,6 +9,8 @@ import (
 
 	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/plugin"
+	"github.com/puppetlabs/wash/plugin/kubernetes"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	crm "google.golang.org/api/cloudresourcemanager/v1"
 	"google.golang.org/api/option"
@@ -18,7 +20,30 @@ import (
 type Root struct {
 	plugin.EntryBase
 	oauthClient *http.Client
+	tokenSource oauth2.TokenSource
 	projects    map[string]struct{}
+	opts        options
+}
+
+// options are the plugin's service-specific options. They're passed to each project.
+type options struct {
+	pubsub    pubsubOptions
+	firestore firestoreOptions
+	// gke configures the Kubernetes contexts of GKE clusters. It takes the same options as
+	// the Kubernetes plugin's config section.
+	gke kubernetes.Options
+}
+
+type pubsubOptions struct {
+	// ack acknowledges messages that are streamed from a subscription.
+	ack bool
+}
+
+type firestoreOptions struct {
+	// merge merges the data that's written to a document's data.json into the document
+	// instead of replacing it.
+	merge   bool
+	queries []firestoreQuery
 }
 
 // serviceScopes lists all scopes used by this module.
@@ -31,8 +56,11 @@ func (r *Root) Init(cfg map[string]interface{}) error {
 
 	// We use the auto-generated SDK because it's the only one that allows us to list
 	// projects for the current credentials.
-	oauthClient, err := google.DefaultClient(context.Background(), serviceScopes...)
-	r.oauthClient = oauthClient
+	//
+	// The token source is also used directly to authenticate with GKE clusters.
+	tokenSource, err := google.DefaultTokenSource(context.Background(), serviceScopes...)
+	r.tokenSource = tokenSource
+	r.oauthClient = oauth2.NewClient(context.Background(), tokenSource)
 
 	if projsI, ok := cfg["projects"]; ok {
 		projs, ok := projsI.([]interface{})
@@ -49,6 +77,53 @@ func (r *Root) Init(cfg map[string]interface{}) error {
 		}
 	}
 
+	if pubsubI, ok := cfg["pubsub"]; ok {
+		pubsubCfg, ok := pubsubI.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("gcp.pubsub config must be an object, not %v", pubsubI)
+		}
+		if ackI, ok := pubsubCfg["ack"]; ok {
+			ack, ok := ackI.(bool)
+			if !ok {
+				return fmt.Errorf("gcp.pubsub.ack config must be a boolean, not %v", ackI)
+			}
+			r.opts.pubsub.ack = ack
+		}
+	}
+
+	if firestoreI, ok := cfg["firestore"]; ok {
+		firestoreCfg, ok := firestoreI.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("gcp.firestore config must be an object, not %v", firestoreI)
+		}
+		if mergeI, ok := firestoreCfg["merge"]; ok {
+			merge, ok := mergeI.(bool)
+			if !ok {
+				return fmt.Errorf("gcp.firestore.merge config must be a boolean, not %v", mergeI)
+			}
+			r.opts.firestore.merge = merge
+		}
+		if queriesI, ok := firestoreCfg["queries"]; ok {
+			queries, err := parseFirestoreQueries(queriesI)
+			if err != nil {
+				return err
+			}
+			r.opts.firestore.queries = queries
+		}
+	}
+
+	var gkeCfg map[string]interface{}
+	if gkeI, ok := cfg["gke"]; ok {
+		if gkeCfg, ok = gkeI.(map[string]interface{}); !ok {
+			return fmt.Errorf("gcp.gke config must be an object, not %v", gkeI)
+		}
+	}
+	gkeOpts, gkeErr := kubernetes.ParseOptions("gcp.gke", gkeCfg)
+	if gkeErr != nil {
+		return gkeErr
+	}
+	r.opts.gke = gkeOpts
+
 	return err
 }
 
@@ -89,7 +164,7 @@ func (r *Root) List(ctx context.Context) ([]plugin.Entry, error) {
 				continue
 			}
 		}
-		projects = append(projects, newProject(proj, r.oauthClient))
+		projects = append(projects, newProject(proj, r.oauthClient, r.tokenSource, r.opts))
 	}
 	return projects, nil
 }
@@ -113,4 +188,23 @@ gcp:
   projects: [project-1, project-2]
 
 to Wash’s config file. Project can be referenced either by name or project ID.
+
+Streaming a Pub/Sub subscription doesn't acknowledge the messages it receives.
+You can acknowledge them by adding
+
+gcp:
+  pubsub:
+    ack: true
+
+to Wash’s config file.
+
+Writing a Firestore document's data.json replaces the document's data. You can
+merge the written data into the document instead by adding
+
+gcp:
+  firestore:
+    merge: true
+
+to Wash’s config file. See the 'firestore' directory's docs for how to save
+Firestore queries.
 `
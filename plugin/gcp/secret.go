@@ -0,0 +1,58 @@
+package gcp
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/puppetlabs/wash/plugin"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+)
+
+type secret struct {
+	plugin.EntryBase
+	service  secretManagerProjectService
+	fullName string
+}
+
+func newSecret(s *secretmanager.Secret, service secretManagerProjectService) *secret {
+	// s.Name is projects/<project>/secrets/<secret>.
+	sec := &secret{
+		EntryBase: plugin.NewEntry(path.Base(s.Name)),
+		service:   service,
+		fullName:  s.Name,
+	}
+	sec.SetPartialMetadata(s)
+	if crtime, err := time.Parse(time.RFC3339, s.CreateTime); err == nil {
+		sec.Attributes().SetCrtime(crtime)
+	}
+	return sec
+}
+
+func (s *secret) List(ctx context.Context) ([]plugin.Entry, error) {
+	var entries []plugin.Entry
+	err := s.service.Projects.Secrets.Versions.List(s.fullName).Pages(ctx, func(resp *secretmanager.ListSecretVersionsResponse) error {
+		for _, version := range resp.Versions {
+			entries = append(entries, newSecretVersion(version, s.service))
+		}
+		return nil
+	})
+	return entries, err
+}
+
+func (s *secret) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(s, "secret").
+		SetDescription(secretDescription).
+		SetPartialMetadataSchema(secretmanager.Secret{})
+}
+
+func (s *secret) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&secretVersion{}).Schema(),
+	}
+}
+
+const secretDescription = `
+This is a Secret Manager secret. It contains the secret's versions, named by
+their version number.
+`
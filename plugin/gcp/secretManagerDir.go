@@ -0,0 +1,63 @@
+package gcp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/puppetlabs/wash/plugin"
+	"google.golang.org/api/option"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+)
+
+type secretManagerProjectService struct {
+	*secretmanager.Service
+	projectID string
+}
+
+type secretManagerDir struct {
+	plugin.EntryBase
+	service secretManagerProjectService
+}
+
+func newSecretManagerDir(ctx context.Context, client *http.Client, projID string) (*secretManagerDir, error) {
+	svc, err := secretmanager.NewService(context.Background(), option.WithHTTPClient(client))
+	if err != nil {
+		return nil, err
+	}
+	sm := &secretManagerDir{
+		EntryBase: plugin.NewEntry("secret_manager"),
+		service:   secretManagerProjectService{Service: svc, projectID: projID},
+	}
+	if _, err := plugin.List(ctx, sm); err != nil {
+		sm.MarkInaccessible(ctx, err)
+	}
+	return sm, nil
+}
+
+func (s *secretManagerDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var entries []plugin.Entry
+	parent := "projects/" + s.service.projectID
+	err := s.service.Projects.Secrets.List(parent).Pages(ctx, func(resp *secretmanager.ListSecretsResponse) error {
+		for _, secret := range resp.Secrets {
+			entries = append(entries, newSecret(secret, s.service))
+		}
+		return nil
+	})
+	return entries, err
+}
+
+func (s *secretManagerDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(s, "secret_manager").
+		SetDescription(secretManagerDirDescription).
+		IsSingleton()
+}
+
+func (s *secretManagerDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&secret{}).Schema(),
+	}
+}
+
+const secretManagerDirDescription = `
+This directory contains the project's Secret Manager secrets.
+`
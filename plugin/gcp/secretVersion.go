@@ -0,0 +1,58 @@
+package gcp
+
+import (
+	"context"
+	"encoding/base64"
+	"path"
+	"time"
+
+	"github.com/puppetlabs/wash/plugin"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+)
+
+type secretVersion struct {
+	plugin.EntryBase
+	service  secretManagerProjectService
+	fullName string
+}
+
+func newSecretVersion(v *secretmanager.SecretVersion, service secretManagerProjectService) *secretVersion {
+	// v.Name is projects/<project>/secrets/<secret>/versions/<version>.
+	version := &secretVersion{
+		EntryBase: plugin.NewEntry(path.Base(v.Name)),
+		service:   service,
+		fullName:  v.Name,
+	}
+	// Don't cache the secret's payload.
+	version.
+		DisableCachingFor(plugin.ReadOp).
+		SetPartialMetadata(v)
+	if crtime, err := time.Parse(time.RFC3339, v.CreateTime); err == nil {
+		version.Attributes().SetCrtime(crtime).SetMtime(crtime)
+	}
+	return version
+}
+
+func (v *secretVersion) Read(ctx context.Context) ([]byte, error) {
+	resp, err := v.service.Projects.Secrets.Versions.Access(v.fullName).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	if resp.Payload == nil {
+		return []byte{}, nil
+	}
+	return base64.StdEncoding.DecodeString(resp.Payload.Data)
+}
+
+func (v *secretVersion) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(v, "version").
+		SetDescription(secretVersionDescription).
+		SetPartialMetadataSchema(secretmanager.SecretVersion{})
+}
+
+const secretVersionDescription = `
+This is a version of a Secret Manager secret. Reading it (e.g. with 'cat')
+accesses the secret's payload at that version. Only enabled versions can be
+read; reading disabled or destroyed versions returns an error. Wash doesn't
+cache the payload.
+`
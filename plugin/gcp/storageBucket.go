@@ -17,10 +17,15 @@ import (
 type storageBucket struct {
 	plugin.EntryBase
 	storageProjectClient
+	versioned bool
 }
 
 func newStorageBucket(client storageProjectClient, bucket *storage.BucketAttrs) *storageBucket {
-	stor := &storageBucket{EntryBase: plugin.NewEntry(bucket.Name), storageProjectClient: client}
+	stor := &storageBucket{
+		EntryBase:            plugin.NewEntry(bucket.Name),
+		storageProjectClient: client,
+		versioned:            bucket.VersioningEnabled,
+	}
 	stor.SetPartialMetadata(bucket).
 		Attributes().
 		SetCrtime(bucket.Created).
@@ -70,7 +75,27 @@ func (s *storageBucket) Metadata(ctx context.Context) (plugin.JSONObject, error)
 // List all storage objects as dirs and files.
 func (s *storageBucket) List(ctx context.Context) ([]plugin.Entry, error) {
 	bucket := s.Bucket(s.Name())
-	return listBucket(ctx, bucket, "")
+	entries, err := listBucket(ctx, bucket, "")
+	if err != nil || !s.versioned {
+		return entries, err
+	}
+
+	// Don't hide an object that has the same name as the versions directory.
+	for _, entry := range entries {
+		if plugin.Name(entry) == storageVersionsDirName {
+			activity.Record(ctx, "Omitting versions of bucket %v because it contains a %v object", s.Name(), storageVersionsDirName)
+			return entries, nil
+		}
+	}
+	return append(entries, newStorageVersionsDir(storageVersionsDirName, bucket, "")), nil
+}
+
+func (s *storageBucket) Create(ctx context.Context, name string, content []byte) (plugin.Entry, error) {
+	return createObject(ctx, s.Bucket(s.Name()), "", name, content)
+}
+
+func (s *storageBucket) CreateDir(ctx context.Context, name string) (plugin.Parent, error) {
+	return createPrefix(ctx, s.Bucket(s.Name()), "", name)
 }
 
 func (s *storageBucket) Delete(ctx context.Context) (bool, error) {
@@ -92,7 +117,7 @@ func (s *storageBucket) Schema() *plugin.EntrySchema {
 }
 
 func (s *storageBucket) ChildSchemas() []*plugin.EntrySchema {
-	return bucketSchemas()
+	return append(bucketSchemas(), (&storageVersionsDir{}).Schema())
 }
 
 const delimiter = "/"
@@ -164,4 +189,11 @@ For example, the objects 'foo/bar' and 'foo/baz' are represented as files with
 path 'foo/bar' and path 'foo/baz', where 'foo' is represented as a 'directory'.
 Thus, if you ls this bucket, then everything you'll see is either a Storage
 object prefix ('directory') or a Storage object ('file').
+
+You can create objects by creating files in the bucket or its prefixes (e.g.
+with 'touch' or output redirection), and prefixes with 'mkdir'. Creating an
+object fails if it already exists.
+
+If the bucket has object versioning enabled, then it also contains a hidden
+.versions directory with every generation of its objects.
 `
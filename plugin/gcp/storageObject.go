@@ -2,20 +2,29 @@ package gcp
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
 
 	"cloud.google.com/go/storage"
 	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/plugin"
+	"google.golang.org/api/googleapi"
 )
 
 type storageObject struct {
 	plugin.EntryBase
 	*storage.ObjectHandle
+	// generation is the generation of the object's content when it was listed. Writes only
+	// succeed if the object's still at that generation.
+	generation int64
+	mux        sync.Mutex
 }
 
 func newStorageObject(name string, object *storage.ObjectHandle, attrs *storage.ObjectAttrs) *storageObject {
-	obj := &storageObject{EntryBase: plugin.NewEntry(name), ObjectHandle: object}
+	obj := &storageObject{EntryBase: plugin.NewEntry(name), ObjectHandle: object, generation: attrs.Generation}
 	obj.SetPartialMetadata(attrs).
 		Attributes().
 		SetCrtime(attrs.Created).
@@ -33,7 +42,79 @@ func (s *storageObject) Schema() *plugin.EntrySchema {
 }
 
 func (s *storageObject) Read(ctx context.Context, size int64, offset int64) ([]byte, error) {
-	rdr, err := s.NewRangeReader(context.Background(), offset, int64(size))
+	return readObject(ctx, s.ObjectHandle, size, offset)
+}
+
+func (s *storageObject) Write(ctx context.Context, p []byte) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	attrs, err := writeObject(ctx, s.ObjectHandle, storage.Conditions{GenerationMatch: s.generation}, p)
+	if isPreconditionFailed(err) {
+		return fmt.Errorf(
+			"%v was modified since it was last listed (expected generation %v); clear its parent's cache and try again",
+			s.ObjectName(),
+			s.generation,
+		)
+	}
+	if err != nil {
+		return err
+	}
+	// Later writes through the same entry are expected to overwrite this one.
+	s.generation = attrs.Generation
+	return nil
+}
+
+func (s *storageObject) Delete(ctx context.Context) (bool, error) {
+	err := s.ObjectHandle.Delete(ctx)
+	return true, err
+}
+
+// Rename copies the object to its new name, then deletes the original. Like Write, it only
+// succeeds if the object hasn't changed since it was listed.
+func (s *storageObject) Rename(ctx context.Context, newParent plugin.Parent, newName string) (plugin.Entry, error) {
+	bucket, prefix, err := renameDestination(newParent)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	src := s.ObjectHandle.If(storage.Conditions{GenerationMatch: s.generation})
+	dst := bucket.Object(prefix + newName)
+	attrs, err := dst.CopierFrom(src).Run(ctx)
+	if isPreconditionFailed(err) {
+		return nil, fmt.Errorf(
+			"%v was modified since it was last listed (expected generation %v); clear its parent's cache and try again",
+			s.ObjectName(),
+			s.generation,
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := src.Delete(ctx); err != nil {
+		return nil, fmt.Errorf("copied %v to %v, but could not delete it: %w", s.ObjectName(), attrs.Name, err)
+	}
+	return newStorageObject(newName, dst, attrs), nil
+}
+
+// renameDestination returns the bucket and prefix that entries are moved to when they're
+// renamed to a child of newParent.
+func renameDestination(newParent plugin.Parent) (*storage.BucketHandle, string, error) {
+	switch p := newParent.(type) {
+	case *storageBucket:
+		return p.Bucket(p.Name()), "", nil
+	case *storageObjectPrefix:
+		return p.bucket, p.prefix, nil
+	default:
+		return nil, "", plugin.ErrCrossParentRename
+	}
+}
+
+func readObject(ctx context.Context, object *storage.ObjectHandle, size int64, offset int64) ([]byte, error) {
+	rdr, err := object.NewRangeReader(context.Background(), offset, int64(size))
 	if err != nil {
 		return nil, err
 	}
@@ -45,23 +126,52 @@ func (s *storageObject) Read(ctx context.Context, size int64, offset int64) ([]b
 	return ioutil.ReadAll(rdr)
 }
 
-func (s *storageObject) Write(ctx context.Context, p []byte) error {
-	wr := s.ObjectHandle.NewWriter(ctx)
+// writeObject replaces the object's content if conds are met and returns its new attributes.
+func writeObject(ctx context.Context, object *storage.ObjectHandle, conds storage.Conditions, p []byte) (*storage.ObjectAttrs, error) {
+	wr := object.If(conds).NewWriter(ctx)
 	if _, err := wr.Write(p); err != nil {
 		wr.Close()
-		return err
+		return nil, err
 	}
 
 	// When Close fails we can assume the object update failed.
-	return wr.Close()
+	if err := wr.Close(); err != nil {
+		return nil, err
+	}
+	return wr.Attrs(), nil
 }
 
-func (s *storageObject) Delete(ctx context.Context) (bool, error) {
-	err := s.ObjectHandle.Delete(ctx)
-	return true, err
+// createObject creates the object prefix+name with the given content. It fails if the object
+// already exists.
+func createObject(ctx context.Context, bucket *storage.BucketHandle, prefix string, name string, content []byte) (*storageObject, error) {
+	object := bucket.Object(prefix + name)
+	attrs, err := writeObject(ctx, object, storage.Conditions{DoesNotExist: true}, content)
+	if isPreconditionFailed(err) {
+		return nil, fmt.Errorf("%v already exists: %w", prefix+name, os.ErrExist)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newStorageObject(name, object, attrs), nil
+}
+
+// isPreconditionFailed returns whether err is the error GCP returns when a request's
+// conditions, like ifGenerationMatch, aren't met.
+func isPreconditionFailed(err error) bool {
+	gerr, ok := err.(*googleapi.Error)
+	return ok && gerr.Code == http.StatusPreconditionFailed
 }
 
 const storageObjectDescription = `
 This is a Storage object. See the bucket's docs for more details
 on why we have this kind of entry.
+
+Writes replace the object's content. They only succeed if the object
+hasn't changed since it was last listed, so concurrent writers can't
+clobber each other's changes. If a write fails because the object was
+modified, clear the cache of the object's parent (e.g. with 'clear') and
+try again.
+
+Storage can't rename objects, so renaming or moving an object (e.g. with
+'mv') copies it to the new name, then deletes the original.
 `
@@ -2,9 +2,13 @@ package gcp
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strings"
 
 	"cloud.google.com/go/storage"
 	"github.com/puppetlabs/wash/plugin"
+	"google.golang.org/api/iterator"
 )
 
 type storageObjectPrefix struct {
@@ -38,6 +42,14 @@ func (s *storageObjectPrefix) List(ctx context.Context) ([]plugin.Entry, error)
 	return listBucket(ctx, s.bucket, s.prefix)
 }
 
+func (s *storageObjectPrefix) Create(ctx context.Context, name string, content []byte) (plugin.Entry, error) {
+	return createObject(ctx, s.bucket, s.prefix, name, content)
+}
+
+func (s *storageObjectPrefix) CreateDir(ctx context.Context, name string) (plugin.Parent, error) {
+	return createPrefix(ctx, s.bucket, s.prefix, name)
+}
+
 func (s *storageObjectPrefix) Delete(ctx context.Context) (bool, error) {
 	err := deleteObjects(ctx, s.bucket, s.prefix)
 	return true, err
@@ -53,7 +65,65 @@ func (s *storageObjectPrefix) ChildSchemas() []*plugin.EntrySchema {
 	return bucketSchemas()
 }
 
+// Rename copies every object under the prefix to the new prefix, then deletes the originals.
+// Only the copied generations are deleted, so objects that are created or overwritten during
+// the rename are kept.
+func (s *storageObjectPrefix) Rename(ctx context.Context, newParent plugin.Parent, newName string) (plugin.Entry, error) {
+	bucket, prefix, err := renameDestination(newParent)
+	if err != nil {
+		return nil, err
+	}
+
+	newPrefix := prefix + newName + delimiter
+	sameBucket := bucket.Object(newPrefix).BucketName() == s.bucket.Object(s.prefix).BucketName()
+	if sameBucket && strings.HasPrefix(newPrefix, s.prefix) {
+		return nil, fmt.Errorf("cannot move %v into itself", s.prefix)
+	}
+	copied := make(map[string]int64)
+	it := s.bucket.Objects(ctx, &storage.Query{Prefix: s.prefix})
+	for {
+		objAttrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		dst := bucket.Object(newPrefix + strings.TrimPrefix(objAttrs.Name, s.prefix))
+		if _, err := dst.CopierFrom(s.bucket.Object(objAttrs.Name)).Run(ctx); err != nil {
+			return nil, fmt.Errorf("failed to copy the %v object: %w", objAttrs.Name, err)
+		}
+		copied[objAttrs.Name] = objAttrs.Generation
+	}
+	for name, generation := range copied {
+		obj := s.bucket.Object(name).If(storage.Conditions{GenerationMatch: generation})
+		if err := obj.Delete(ctx); err != nil && !isPreconditionFailed(err) {
+			return nil, fmt.Errorf("copied %v to %v, but could not delete the %v object: %w", s.prefix, newPrefix, name, err)
+		}
+	}
+	return newStorageObjectPrefix(bucket, newName, newPrefix, nil), nil
+}
+
+// createPrefix creates an empty prefix. Storage doesn't have directories, so like the Cloud
+// Console, we create an empty object whose name is the new prefix.
+func createPrefix(ctx context.Context, bucket *storage.BucketHandle, prefix string, name string) (*storageObjectPrefix, error) {
+	newPrefix := prefix + name + delimiter
+	attrs, err := writeObject(ctx, bucket.Object(newPrefix), storage.Conditions{DoesNotExist: true}, []byte{})
+	if isPreconditionFailed(err) {
+		return nil, fmt.Errorf("%v already exists: %w", newPrefix, os.ErrExist)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newStorageObjectPrefix(bucket, name, newPrefix, attrs), nil
+}
+
 const storageObjectPrefixDescription = `
 This represents a common prefix shared by multiple Storage objects. See
 the bucket's docs for more details on why we have this kind of entry.
+
+New prefixes (e.g. from 'mkdir') are created by writing an empty object
+whose name ends in a '/', which is what the Cloud Console does. Renaming a
+prefix (e.g. with 'mv') copies each of its objects to the new prefix, then
+deletes the originals.
 `
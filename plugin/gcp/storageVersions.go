@@ -0,0 +1,207 @@
+package gcp
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/puppetlabs/wash/plugin"
+	"google.golang.org/api/iterator"
+)
+
+// storageVersionsDirName is the name of a versioned bucket's versions directory. The leading
+// dot hides it from ls and makes it unlikely to conflict with an object.
+const storageVersionsDirName = ".versions"
+
+// storageVersionsDir mirrors the bucket's hierarchy of prefixes, but lists every object that
+// has generations (including deleted objects) instead of only the live objects.
+type storageVersionsDir struct {
+	plugin.EntryBase
+	bucket *storage.BucketHandle
+	prefix string
+}
+
+func newStorageVersionsDir(name string, bucket *storage.BucketHandle, prefix string) *storageVersionsDir {
+	return &storageVersionsDir{
+		EntryBase: plugin.NewEntry(name),
+		bucket:    bucket,
+		prefix:    prefix,
+	}
+}
+
+func (d *storageVersionsDir) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(d, "versions").
+		SetDescription(storageVersionsDirDescription)
+}
+
+func (d *storageVersionsDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&storageVersionsDir{}).Schema(),
+		(&storageObjectGenerations{}).Schema(),
+	}
+}
+
+type storageObjectGenerationsMetadata struct {
+	Name string
+	// Deleted is true if the object has no live generation.
+	Deleted bool
+	Updated time.Time
+}
+
+// List lists the prefixes and objects directly under the current prefix. It uses the same
+// delimiter semantics as listBucket.
+func (d *storageVersionsDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var entries []plugin.Entry
+	objects := make(map[string]*storageObjectGenerationsMetadata)
+	it := d.bucket.Objects(ctx, &storage.Query{Delimiter: delimiter, Prefix: d.prefix, Versions: true})
+	for {
+		objAttrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if objAttrs.Prefix != "" {
+			name := strings.TrimPrefix(strings.TrimSuffix(objAttrs.Prefix, delimiter), d.prefix)
+			entries = append(entries, newStorageVersionsDir(name, d.bucket, objAttrs.Prefix))
+			continue
+		}
+		if objAttrs.Name == d.prefix {
+			continue
+		}
+		meta, ok := objects[objAttrs.Name]
+		if !ok {
+			meta = &storageObjectGenerationsMetadata{Name: objAttrs.Name, Deleted: true}
+			objects[objAttrs.Name] = meta
+		}
+		// Noncurrent generations have a Deleted time.
+		if objAttrs.Deleted.IsZero() {
+			meta.Deleted = false
+		}
+		// An object's last update is either its latest generation or when it was deleted.
+		updated := objAttrs.Updated
+		if objAttrs.Deleted.After(updated) {
+			updated = objAttrs.Deleted
+		}
+		if updated.After(meta.Updated) {
+			meta.Updated = updated
+		}
+	}
+
+	for name, meta := range objects {
+		entries = append(entries, newStorageObjectGenerations(strings.TrimPrefix(name, d.prefix), d.bucket, meta))
+	}
+	return entries, nil
+}
+
+// storageObjectGenerations represents all the generations of an object.
+type storageObjectGenerations struct {
+	plugin.EntryBase
+	bucket *storage.BucketHandle
+	object string
+}
+
+func newStorageObjectGenerations(name string, bucket *storage.BucketHandle, meta *storageObjectGenerationsMetadata) *storageObjectGenerations {
+	gens := &storageObjectGenerations{
+		EntryBase: plugin.NewEntry(name),
+		bucket:    bucket,
+		object:    meta.Name,
+	}
+	gens.SetPartialMetadata(meta).
+		Attributes().
+		SetMtime(meta.Updated)
+	return gens
+}
+
+func (g *storageObjectGenerations) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(g, "object_generations").
+		SetDescription(storageObjectGenerationsDescription).
+		SetPartialMetadataSchema(storageObjectGenerationsMetadata{})
+}
+
+func (g *storageObjectGenerations) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&storageObjectGeneration{}).Schema(),
+	}
+}
+
+func (g *storageObjectGenerations) List(ctx context.Context) ([]plugin.Entry, error) {
+	var entries []plugin.Entry
+	it := g.bucket.Objects(ctx, &storage.Query{Prefix: g.object, Versions: true})
+	for {
+		objAttrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// Prefix also matches longer names, so only keep the object's own generations.
+		if objAttrs.Name == g.object {
+			entries = append(entries, newStorageObjectGeneration(g.bucket, objAttrs))
+		}
+	}
+	return entries, nil
+}
+
+// storageObjectGeneration represents a single generation of a Storage object.
+type storageObjectGeneration struct {
+	plugin.EntryBase
+	*storage.ObjectHandle
+}
+
+func newStorageObjectGeneration(bucket *storage.BucketHandle, attrs *storage.ObjectAttrs) *storageObjectGeneration {
+	gen := &storageObjectGeneration{
+		EntryBase:    plugin.NewEntry(strconv.FormatInt(attrs.Generation, 10)),
+		ObjectHandle: bucket.Object(attrs.Name).Generation(attrs.Generation),
+	}
+	// A generation's content never changes, so its mtime is when it was created.
+	gen.SetPartialMetadata(attrs).
+		Attributes().
+		SetCrtime(attrs.Created).
+		SetCtime(attrs.Updated).
+		SetMtime(attrs.Created).
+		SetSize(uint64(attrs.Size))
+	return gen
+}
+
+func (g *storageObjectGeneration) Schema() *plugin.EntrySchema {
+	return plugin.NewEntrySchema(g, "generation").
+		SetDescription(storageObjectGenerationDescription).
+		SetPartialMetadataSchema(storage.ObjectAttrs{})
+}
+
+func (g *storageObjectGeneration) Read(ctx context.Context, size int64, offset int64) ([]byte, error) {
+	return readObject(ctx, g.ObjectHandle, size, offset)
+}
+
+func (g *storageObjectGeneration) Delete(ctx context.Context) (bool, error) {
+	err := g.ObjectHandle.Delete(ctx)
+	return true, err
+}
+
+const storageVersionsDirDescription = `
+This directory contains every generation of the objects in a bucket with
+object versioning enabled. It groups objects into prefixes the same way as the
+bucket does, and includes objects that have been deleted. You can find the
+objects that were deleted in the last day with something like
+
+find .versions -meta .Deleted -true -mtime -1d
+`
+
+const storageObjectGenerationsDescription = `
+This directory contains all generations of a Storage object, including
+noncurrent generations. Its mtime is when the object was last updated, which
+is when it was deleted if it has no live generation.
+`
+
+const storageObjectGenerationDescription = `
+This is a generation of a Storage object, named by its generation number. You
+can read it (e.g. with 'cat') to see the object's content at that generation.
+Its metadata's Deleted time is set if it's a noncurrent generation. Deleting it
+permanently removes the generation.
+`
@@ -0,0 +1,137 @@
+package kubernetes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// allDir merges resources across all accessible contexts.
+type allDir struct {
+	plugin.EntryBase
+	contexts []*k8context
+	opts     options
+}
+
+func newAllDir(contexts []*k8context, opts options) *allDir {
+	all := &allDir{
+		EntryBase: plugin.NewEntry(allDirName),
+	}
+	all.contexts = contexts
+	all.opts = opts
+	return all
+}
+
+func (a *allDir) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(a, allDirName).
+		SetDescription(allDirDescription).
+		IsSingleton()
+}
+
+func (a *allDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&allPodsDir{}).Schema(),
+	}
+}
+
+func (a *allDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	return []plugin.Entry{newAllPodsDir(a)}, nil
+}
+
+// allPodsDir lists pods from all namespaces of all accessible contexts. Each pod is named
+// <context>/<namespace>/<pod>, which is displayed as <context>#<namespace>#<pod>.
+type allPodsDir struct {
+	plugin.EntryBase
+	contexts []*k8context
+	opts     options
+}
+
+func newAllPodsDir(all *allDir) *allPodsDir {
+	pds := &allPodsDir{
+		EntryBase: plugin.NewEntry("pods"),
+	}
+	pds.contexts = all.contexts
+	pds.opts = all.opts
+	return pds
+}
+
+func (ps *allPodsDir) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(ps, "pods").
+		SetDescription(allPodsDirDescription).
+		IsSingleton()
+}
+
+func (ps *allPodsDir) ChildSchemas() []*plugin.EntrySchema {
+	return []*plugin.EntrySchema{
+		(&pod{}).Schema(),
+	}
+}
+
+func (ps *allPodsDir) List(ctx context.Context) ([]plugin.Entry, error) {
+	var mux sync.Mutex
+	var wg sync.WaitGroup
+	entries := make([]plugin.Entry, 0)
+	for _, k8ctx := range ps.contexts {
+		if k8ctx.IsInaccessible() {
+			continue
+		}
+
+		wg.Add(1)
+		go func(k8ctx *k8context) {
+			defer wg.Done()
+			pods, err := ps.listPods(ctx, k8ctx)
+			if err != nil {
+				activity.Warnf(ctx, "Omitting pods in context %v from %v: %v", k8ctx.Name(), ps.ID(), err)
+				return
+			}
+			mux.Lock()
+			entries = append(entries, pods...)
+			mux.Unlock()
+		}(k8ctx)
+	}
+	wg.Wait()
+	return entries, nil
+}
+
+func (ps *allPodsDir) listPods(ctx context.Context, k8ctx *k8context) ([]plugin.Entry, error) {
+	ctx, cancel := context.WithTimeout(ctx, ps.opts.contextTimeout)
+	defer cancel()
+
+	listOpts := metav1.ListOptions{LabelSelector: ps.opts.allPodsSelector}
+	podList, err := k8ctx.client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, listOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]plugin.Entry, len(podList.Items))
+	for i, p := range podList.Items {
+		name := k8ctx.Name() + "/" + p.Namespace + "/" + p.Name
+		pd, err := newPodEntry(ctx, name, k8ctx.client, k8ctx.config, p.Namespace, ps.opts, &p)
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = pd
+	}
+	return entries, nil
+}
+
+const allDirDescription = `
+This directory merges resources across all accessible Kubernetes contexts, so
+you can search your whole fleet with a single find.
+`
+
+const allPodsDirDescription = `
+These are the pods in all namespaces of all accessible contexts. Each pod is
+named <context>#<namespace>#<pod>. You can limit the pods to those matching a
+label selector by adding
+
+kubernetes:
+  all_pods_selector: app=nginx
+
+to Wash's config file.
+`
@@ -1,9 +1,7 @@
 package kubernetes
 
 import (
-	"bytes"
 	"context"
-	"fmt"
 	"io"
 
 	"github.com/puppetlabs/wash/activity"
@@ -16,6 +14,7 @@ type containerLogFile struct {
 	plugin.EntryBase
 	namespace, podName, containerName string
 	client                            *k8s.Clientset
+	opts                              options
 }
 
 func newContainerLogFile(container *container) *containerLogFile {
@@ -26,6 +25,7 @@ func newContainerLogFile(container *container) *containerLogFile {
 	clf.podName = container.pod.Name
 	clf.containerName = container.Name()
 	clf.client = container.client
+	clf.opts = container.opts
 	return clf
 }
 
@@ -33,32 +33,75 @@ func (clf *containerLogFile) Schema() *plugin.EntrySchema {
 	return plugin.NewEntrySchema(clf, "log").IsSingleton()
 }
 
-func (clf *containerLogFile) Read(ctx context.Context) ([]byte, error) {
+// ReadStream streams the log rather than reading it into memory because container logs can
+// be very large. Kubernetes doesn't report the log's size, so ls and stat report it as 0.
+func (clf *containerLogFile) ReadStream(ctx context.Context) (io.ReadCloser, error) {
 	logOptions := corev1.PodLogOptions{
-		Container: clf.containerName,
+		Container:  clf.containerName,
+		Timestamps: clf.opts.logTimestamps,
 	}
-	req := clf.client.CoreV1().Pods(clf.namespace).GetLogs(clf.podName, &logOptions)
-	rdr, err := req.Stream(ctx)
-	if err != nil {
-		return nil, err
+	return streamLog(ctx, clf.client, clf.namespace, clf.podName, &logOptions)
+}
+
+func (clf *containerLogFile) Stream(ctx context.Context) (io.ReadCloser, error) {
+	logOptions := corev1.PodLogOptions{
+		Container:  clf.containerName,
+		Follow:     true,
+		Timestamps: clf.opts.logTimestamps,
 	}
-	var buf bytes.Buffer
-	var n int64
-	if n, err = buf.ReadFrom(rdr); err != nil {
-		return nil, fmt.Errorf("unable to read logs: %v", err)
+	if clf.opts.logSince > 0 {
+		sinceSeconds := int64(clf.opts.logSince.Seconds())
+		logOptions.SinceSeconds = &sinceSeconds
+	} else {
+		var tailLines int64 = 10
+		logOptions.TailLines = &tailLines
 	}
-	activity.Record(ctx, "Read %v bytes of %v log", n, clf.containerName)
+	return streamLog(ctx, clf.client, clf.namespace, clf.podName, &logOptions)
+}
 
-	return buf.Bytes(), nil
+// containerPreviousLogFile is the log of the container's last terminated instance. It's
+// useful for finding out why a container in a crash loop keeps restarting.
+type containerPreviousLogFile struct {
+	plugin.EntryBase
+	namespace, podName, containerName string
+	client                            *k8s.Clientset
+	opts                              options
 }
 
-func (clf *containerLogFile) Stream(ctx context.Context) (io.ReadCloser, error) {
-	var tailLines int64 = 10
+func newContainerPreviousLogFile(container *container) *containerPreviousLogFile {
+	clf := &containerPreviousLogFile{
+		EntryBase: plugin.NewEntry("previous.log"),
+	}
+	clf.namespace = container.pod.Namespace
+	clf.podName = container.pod.Name
+	clf.containerName = container.Name()
+	clf.client = container.client
+	clf.opts = container.opts
+	return clf
+}
+
+func (clf *containerPreviousLogFile) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(clf, "previous.log").
+		SetDescription(containerPreviousLogFileDescription).
+		IsSingleton()
+}
+
+func (clf *containerPreviousLogFile) ReadStream(ctx context.Context) (io.ReadCloser, error) {
 	logOptions := corev1.PodLogOptions{
-		Container: clf.containerName,
-		Follow:    true,
-		TailLines: &tailLines,
+		Container:  clf.containerName,
+		Previous:   true,
+		Timestamps: clf.opts.logTimestamps,
 	}
-	req := clf.client.CoreV1().Pods(clf.namespace).GetLogs(clf.podName, &logOptions)
-	return req.Stream(ctx)
+	return streamLog(ctx, clf.client, clf.namespace, clf.podName, &logOptions)
 }
+
+func streamLog(ctx context.Context, client *k8s.Clientset, namespace, podName string, logOptions *corev1.PodLogOptions) (io.ReadCloser, error) {
+	activity.Record(ctx, "Streaming %v log of pod %v", logOptions.Container, podName)
+	return client.CoreV1().Pods(namespace).GetLogs(podName, logOptions).Stream(ctx)
+}
+
+const containerPreviousLogFileDescription = `
+This is the log of the container's previous instance. It's only present if the
+container was restarted, which makes it useful for debugging crash loops.
+`
@@ -16,9 +16,10 @@ import (
 type container struct {
 	plugin.EntryBase
 	containerBase
+	opts options
 }
 
-func newContainer(ctx context.Context, client *k8s.Clientset, config *rest.Config, c *corev1.Container, p *corev1.Pod) (*container, error) {
+func newContainer(ctx context.Context, client *k8s.Clientset, config *rest.Config, opts options, c *corev1.Container, p *corev1.Pod) (*container, error) {
 	cntnr := &container{
 		EntryBase: plugin.NewEntry(c.Name),
 	}
@@ -26,6 +27,7 @@ func newContainer(ctx context.Context, client *k8s.Clientset, config *rest.Confi
 	cntnr.config = config
 	cntnr.pod = p
 	cntnr.container = c
+	cntnr.opts = opts
 
 	// Find when the container was started; set this as the creation time
 	for _, ecs := range cntnr.pod.Status.ContainerStatuses {
@@ -55,6 +57,7 @@ func (c *container) Schema() *plugin.EntrySchema {
 func (c *container) ChildSchemas() []*plugin.EntrySchema {
 	return []*plugin.EntrySchema{
 		(&containerLogFile{}).Schema(),
+		(&containerPreviousLogFile{}).Schema(),
 		(&plugin.MetadataJSONFile{}).Schema(),
 		(&volume.FS{}).Schema(),
 	}
@@ -71,7 +74,22 @@ func (c *container) List(ctx context.Context) ([]plugin.Entry, error) {
 
 	// Include a view of the remote filesystem using volume.FS. Use a small maxdepth because
 	// VMs can have lots of files and Exec is fast.
-	return []plugin.Entry{clf, cm, volume.NewFS(ctx, "fs", c, 3)}, nil
+	entries := []plugin.Entry{clf, cm, volume.NewFS(ctx, "fs", c, 3)}
+
+	// Only include the previous log if an earlier instance of the container terminated.
+	if c.hasPreviousInstance() {
+		entries = append(entries, newContainerPreviousLogFile(c))
+	}
+	return entries, nil
+}
+
+func (c *container) hasPreviousInstance() bool {
+	for _, cs := range c.pod.Status.ContainerStatuses {
+		if cs.Name == c.Name() {
+			return cs.LastTerminationState.Terminated != nil
+		}
+	}
+	return false
 }
 
 func (c *container) Exec(ctx context.Context, cmd string, args []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
@@ -15,18 +15,37 @@ type k8context struct {
 	client    *k8s.Clientset
 	config    *rest.Config
 	defaultns string
+	opts      options
 }
 
-func newK8Context(name string, client *k8s.Clientset, config *rest.Config, defaultns string) *k8context {
+func newK8Context(name string, client *k8s.Clientset, config *rest.Config, defaultns string, opts options) *k8context {
 	context := &k8context{
 		EntryBase: plugin.NewEntry(name),
 	}
 	context.client = client
 	context.config = config
 	context.defaultns = defaultns
+	context.opts = opts
 	return context
 }
 
+// NewContext creates an entry for the cluster that config connects to. Listing it returns
+// the cluster's namespaces with the same hierarchy as the Kubernetes plugin. It's used by
+// other plugins, like GCP, that can connect to clusters without a kubeconfig. Use ParseOptions
+// to get opts from the Kubernetes plugin's config.
+func NewContext(name string, config *rest.Config, opts Options) (plugin.Parent, error) {
+	clientset, err := k8s.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return newK8Context(name, clientset, config, metav1.NamespaceDefault, opts.opts), nil
+}
+
+// ContextChildSchemas returns the schemas of the children of entries created by NewContext.
+func ContextChildSchemas() []*plugin.EntrySchema {
+	return (&k8context{}).ChildSchemas()
+}
+
 func (c *k8context) Schema() *plugin.EntrySchema {
 	return plugin.
 		NewEntrySchema(c, "context").
@@ -48,17 +67,25 @@ func (c *k8context) List(ctx context.Context) ([]plugin.Entry, error) {
 		if err != nil {
 			activity.Record(ctx, "Error loading default namespace, metadata will not be available: %v", err)
 		}
-		return []plugin.Entry{newNamespace(c.defaultns, ns, c.client, c.config)}, nil
+		return []plugin.Entry{newNamespace(c.defaultns, ns, c.client, c.config, c.opts)}, nil
 	}
 
 	namespaces := make([]plugin.Entry, len(nsList.Items))
 	for i, ns := range nsList.Items {
-		namespaces[i] = newNamespace(ns.Name, &ns, c.client, c.config)
+		namespaces[i] = newNamespace(ns.Name, &ns, c.client, c.config, c.opts)
 	}
 	activity.Record(ctx, "Listing namespaces: %+v", namespaces)
 	return namespaces, nil
 }
 
+// checkReachable checks that the context's cluster responds within the context timeout.
+func (c *k8context) checkReachable(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.contextTimeout)
+	defer cancel()
+	_, err := c.client.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Raw()
+	return err
+}
+
 const contextDescription = `
 This is a Kubernetes context.
 `
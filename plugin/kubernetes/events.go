@@ -0,0 +1,156 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+// eventsFile represents a namespace's events. Reading it returns the events that are
+// currently stored; streaming it follows new events via the watch API.
+type eventsFile struct {
+	plugin.EntryBase
+	client *k8s.Clientset
+	ns     string
+}
+
+func newEventsFile(ns *namespace) *eventsFile {
+	ev := &eventsFile{
+		EntryBase: plugin.NewEntry("events"),
+	}
+	ev.client = ns.client
+	ev.ns = ns.Name()
+	ev.DisableCachingFor(plugin.ReadOp)
+	return ev
+}
+
+func (ev *eventsFile) Schema() *plugin.EntrySchema {
+	return plugin.
+		NewEntrySchema(ev, "events").
+		SetDescription(eventsFileDescription).
+		IsSingleton()
+}
+
+func (ev *eventsFile) list(ctx context.Context) (*corev1.EventList, error) {
+	eventList, err := ev.client.CoreV1().Events(ev.ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(eventList.Items, func(i, j int) bool {
+		return eventTime(&eventList.Items[i]).Before(eventTime(&eventList.Items[j]))
+	})
+	return eventList, nil
+}
+
+func (ev *eventsFile) Read(ctx context.Context) ([]byte, error) {
+	eventList, err := ev.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for i := range eventList.Items {
+		buf.WriteString(formatEvent(&eventList.Items[i]))
+	}
+	activity.Record(ctx, "Read %v events in namespace %v", len(eventList.Items), ev.ns)
+	return buf.Bytes(), nil
+}
+
+func (ev *eventsFile) Stream(ctx context.Context) (io.ReadCloser, error) {
+	// Start with the last few events, similar to how container logs are streamed, then
+	// watch for new events starting from the resource version we listed.
+	eventList, err := ev.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	watcher, err := ev.client.CoreV1().Events(ev.ns).Watch(ctx, metav1.ListOptions{
+		ResourceVersion: eventList.ResourceVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recent := eventList.Items
+	if len(recent) > 10 {
+		recent = recent[len(recent)-10:]
+	}
+
+	r, w := io.Pipe()
+	go func() {
+		for i := range recent {
+			if _, err := io.WriteString(w, formatEvent(&recent[i])); err != nil {
+				return
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				w.CloseWithError(ctx.Err())
+				return
+			case e, ok := <-watcher.ResultChan():
+				if !ok {
+					activity.Record(ctx, "Stopped watching events in namespace %v", ev.ns)
+					w.Close()
+					return
+				}
+				switch e.Type {
+				case watch.Added, watch.Modified:
+					event, ok := e.Object.(*corev1.Event)
+					if !ok {
+						continue
+					}
+					if _, err := io.WriteString(w, formatEvent(event)); err != nil {
+						// The reader was closed.
+						return
+					}
+				case watch.Error:
+					w.CloseWithError(fmt.Errorf("error watching events in namespace %v: %v", ev.ns, e.Object))
+					return
+				}
+			}
+		}
+	}()
+
+	return plugin.CleanupReader{ReadCloser: r, Cleanup: watcher.Stop}, nil
+}
+
+// eventTime returns the most recent time the event was observed.
+func eventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+func formatEvent(event *corev1.Event) string {
+	return fmt.Sprintf(
+		"%v %v %v %v/%v: %v\n",
+		eventTime(event).Format(time.RFC3339),
+		event.Type,
+		event.Reason,
+		event.InvolvedObject.Kind,
+		event.InvolvedObject.Name,
+		event.Message,
+	)
+}
+
+const eventsFileDescription = `
+These are the namespace's events. Reading them returns all stored events ordered
+by when they were last seen. Streaming them (e.g. 'tail -f events') starts with
+the 10 most recent events and then follows new ones as they happen.
+`
@@ -14,18 +14,21 @@ type namespace struct {
 	plugin.EntryBase
 	client    *k8s.Clientset
 	config    *rest.Config
+	opts      options
 	resources []plugin.Entry
 }
 
-func newNamespace(name string, meta *corev1.Namespace, c *k8s.Clientset, cfg *rest.Config) *namespace {
+func newNamespace(name string, meta *corev1.Namespace, c *k8s.Clientset, cfg *rest.Config, opts options) *namespace {
 	ns := &namespace{
 		EntryBase: plugin.NewEntry(name),
 	}
 	ns.client = c
 	ns.config = cfg
+	ns.opts = opts
 	ns.resources = []plugin.Entry{
 		newPodsDir(ns),
 		newPVCSDir(ns),
+		newEventsFile(ns),
 	}
 	// TODO: Figure out other attributes that we could set here, if any.
 	ns.SetPartialMetadata(meta)
@@ -43,6 +46,7 @@ func (n *namespace) ChildSchemas() []*plugin.EntrySchema {
 	return []*plugin.EntrySchema{
 		(&podsDir{}).Schema(),
 		(&pvcsDir{}).Schema(),
+		(&eventsFile{}).Schema(),
 	}
 }
 
@@ -2,12 +2,19 @@ package kubernetes
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net/http"
 
+	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/plugin"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	k8s "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
 )
 
 type pod struct {
@@ -15,15 +22,25 @@ type pod struct {
 	client *k8s.Clientset
 	config *rest.Config
 	ns     string
+	name   string
+	opts   options
 }
 
-func newPod(ctx context.Context, client *k8s.Clientset, config *rest.Config, ns string, p *corev1.Pod) (*pod, error) {
+func newPod(ctx context.Context, client *k8s.Clientset, config *rest.Config, ns string, opts options, p *corev1.Pod) (*pod, error) {
+	return newPodEntry(ctx, p.Name, client, config, ns, opts, p)
+}
+
+// newPodEntry creates a pod whose entry name may differ from the pod's name. Directories
+// that merge pods from several namespaces or contexts use it to keep names unique.
+func newPodEntry(ctx context.Context, entryName string, client *k8s.Clientset, config *rest.Config, ns string, opts options, p *corev1.Pod) (*pod, error) {
 	pd := &pod{
-		EntryBase: plugin.NewEntry(p.Name),
+		EntryBase: plugin.NewEntry(entryName),
 	}
+	pd.name = p.Name
 	pd.client = client
 	pd.config = config
 	pd.ns = ns
+	pd.opts = opts
 
 	pd.
 		SetPartialMetadata(p).
@@ -37,7 +54,9 @@ func newPod(ctx context.Context, client *k8s.Clientset, config *rest.Config, ns
 func (p *pod) Schema() *plugin.EntrySchema {
 	return plugin.
 		NewEntrySchema(p, "pod").
-		SetPartialMetadataSchema(corev1.Pod{})
+		SetDescription(podDescription).
+		SetPartialMetadataSchema(corev1.Pod{}).
+		AddSignal("debug", "Attaches an ephemeral debug container that targets the pod's first container")
 }
 
 func (p *pod) ChildSchemas() []*plugin.EntrySchema {
@@ -47,25 +66,123 @@ func (p *pod) ChildSchemas() []*plugin.EntrySchema {
 }
 
 func (p *pod) List(ctx context.Context) ([]plugin.Entry, error) {
-	pd, err := p.client.CoreV1().Pods(p.ns).Get(ctx, p.Name(), metav1.GetOptions{})
+	pd, err := p.client.CoreV1().Pods(p.ns).Get(ctx, p.name, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
 
-	entries := make([]plugin.Entry, len(pd.Spec.Containers))
-	for i, c := range pd.Spec.Containers {
-		c, err := newContainer(ctx, p.client, p.config, &c, pd)
+	entries := make([]plugin.Entry, 0, len(pd.Spec.Containers)+len(pd.Spec.EphemeralContainers))
+	for _, c := range pd.Spec.Containers {
+		c, err := newContainer(ctx, p.client, p.config, p.opts, &c, pd)
 		if err != nil {
 			return nil, err
 		}
 
-		entries[i] = c
+		entries = append(entries, c)
+	}
+
+	// Ephemeral containers share the fields of regular containers, so we can exec in them
+	// the same way. This is how containers created by the debug signal are accessed.
+	for _, ec := range pd.Spec.EphemeralContainers {
+		spec := corev1.Container(ec.EphemeralContainerCommon)
+		c, err := newContainer(ctx, p.client, p.config, p.opts, &spec, pd)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, c)
 	}
 
 	return entries, nil
 }
 
 func (p *pod) Delete(ctx context.Context) (bool, error) {
-	err := p.client.CoreV1().Pods(p.ns).Delete(ctx, p.Name(), metav1.DeleteOptions{})
+	err := p.client.CoreV1().Pods(p.ns).Delete(ctx, p.name, metav1.DeleteOptions{})
 	return true, err
 }
+
+func (p *pod) Signal(ctx context.Context, signal string) error {
+	switch signal {
+	case "debug":
+		return p.attachDebugContainer(ctx)
+	default:
+		return fmt.Errorf("unknown signal %v", signal)
+	}
+}
+
+// attachDebugContainer adds an ephemeral container that shares the process namespace of
+// the pod's first container. It waits on stdin so that it keeps running until someone
+// execs into it and exits.
+func (p *pod) attachDebugContainer(ctx context.Context) error {
+	podi := p.client.CoreV1().Pods(p.ns)
+	pd, err := podi.Get(ctx, p.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if len(pd.Spec.Containers) == 0 {
+		return fmt.Errorf("pod %v has no containers to debug", p.name)
+	}
+
+	ecs, err := podi.GetEphemeralContainers(ctx, p.name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to get ephemeral containers, the cluster may not support them: %v", err)
+	}
+
+	name := "debugger-" + utilrand.String(5)
+	ecs.EphemeralContainers = append(ecs.EphemeralContainers, corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     name,
+			Image:                    p.opts.debugImage,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		},
+		TargetContainerName: pd.Spec.Containers[0].Name,
+	})
+	if _, err := podi.UpdateEphemeralContainers(ctx, p.name, ecs, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("unable to add debug container: %v", err)
+	}
+	activity.Record(ctx, "Added debug container %v to pod %v", name, p.name)
+	return nil
+}
+
+// Forward forwards local ports to the pod using the same rest config as exec.
+func (p *pod) Forward(ctx context.Context, ports []string, out io.Writer) error {
+	transport, upgrader, err := spdy.RoundTripperFor(p.config)
+	if err != nil {
+		return err
+	}
+	req := p.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(p.ns).
+		Name(p.name).
+		SubResource("portforward")
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	fw, err := portforward.New(dialer, ports, ctx.Done(), nil, out, out)
+	if err != nil {
+		return err
+	}
+	activity.Record(ctx, "Forwarding %v to pod %v", ports, p.name)
+	return fw.ForwardPorts()
+}
+
+const podDescription = `
+This is a Kubernetes pod. Its containers are listed as children, including any
+ephemeral containers.
+
+The debug signal attaches an ephemeral container that shares the process
+namespace of the pod's first container. This is useful for debugging distroless
+pods where exec fails; exec into the new debugger-* container instead. The
+container's image defaults to busybox and can be changed with
+
+kubernetes:
+  debug_image: <image>
+
+in Wash's config file.
+
+The forward action forwards local ports to the pod, e.g.
+
+  forward <pod> 8080:80
+`
@@ -14,6 +14,7 @@ type podsDir struct {
 	client *k8s.Clientset
 	config *rest.Config
 	ns     string
+	opts   options
 }
 
 func newPodsDir(ns *namespace) *podsDir {
@@ -23,6 +24,7 @@ func newPodsDir(ns *namespace) *podsDir {
 	pds.client = ns.client
 	pds.config = ns.config
 	pds.ns = ns.Name()
+	pds.opts = ns.opts
 	return pds
 }
 
@@ -45,7 +47,7 @@ func (ps *podsDir) List(ctx context.Context) ([]plugin.Entry, error) {
 	}
 	entries := make([]plugin.Entry, len(podList.Items))
 	for i, p := range podList.Items {
-		pd, err := newPod(ctx, ps.client, ps.config, ps.ns, &p)
+		pd, err := newPod(ctx, ps.client, ps.config, ps.ns, ps.opts, &p)
 		if err != nil {
 			return nil, err
 		}
@@ -3,6 +3,7 @@ package kubernetes
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"os"
 
@@ -15,6 +16,7 @@ import (
 	typedv1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
+	k8exec "k8s.io/client-go/util/exec"
 )
 
 type pvc struct {
@@ -24,9 +26,10 @@ type pvc struct {
 	client    *k8s.Clientset
 	config    *rest.Config
 	namespace string
+	opts      options
 }
 
-func newPVC(pi typedv1.PersistentVolumeClaimInterface, client *k8s.Clientset, config *rest.Config, ns string, p *corev1.PersistentVolumeClaim) *pvc {
+func newPVC(pi typedv1.PersistentVolumeClaimInterface, client *k8s.Clientset, config *rest.Config, ns string, opts options, p *corev1.PersistentVolumeClaim) *pvc {
 	vol := &pvc{
 		EntryBase: plugin.NewEntry(p.Name),
 	}
@@ -35,6 +38,7 @@ func newPVC(pi typedv1.PersistentVolumeClaimInterface, client *k8s.Clientset, co
 	vol.client = client
 	vol.config = config
 	vol.namespace = ns
+	vol.opts = opts
 
 	vol.SetTTLOf(plugin.ListOp, volume.ListTTL)
 	vol.
@@ -63,6 +67,14 @@ func (v *pvc) List(ctx context.Context) ([]plugin.Entry, error) {
 	return volume.List(ctx, v)
 }
 
+func (v *pvc) Create(ctx context.Context, name string, content []byte) (plugin.Entry, error) {
+	return volume.Create(ctx, v, name, content)
+}
+
+func (v *pvc) CreateDir(ctx context.Context, name string) (plugin.Parent, error) {
+	return volume.CreateDir(ctx, v, name)
+}
+
 func (v *pvc) Delete(ctx context.Context) (bool, error) {
 	err := v.pvci.Delete(ctx, v.Name(), metav1.DeleteOptions{})
 	return true, err
@@ -87,6 +99,11 @@ func (v *pvc) getFirstMountingPod(ctx context.Context) (*corev1.Pod, string, err
 		if pod.Status.Phase != corev1.PodRunning {
 			continue
 		}
+		// Helper pods are only used through their lease, which keeps them alive while
+		// they're used.
+		if pod.Labels[managedByLabel] == managedByWash {
+			continue
+		}
 
 		for _, vol := range pod.Spec.Volumes {
 			if vol.PersistentVolumeClaim != nil && vol.PersistentVolumeClaim.ClaimName == v.Name() {
@@ -119,8 +136,8 @@ func (v *pvc) getMountInfo(pod *corev1.Pod, volumeName string) mountInfo {
 // lifetime of the inContainer function call.
 type containerCb = func(c *containerBase, mountpoint string, cleanup func()) (interface{}, error)
 
-// Execution containerCb in a container that has the current PVC mounted. Creates one if one is
-// not currently running.
+// Execution containerCb in a container that has the current PVC mounted. If no running pod mounts
+// it, we use a lease-owned helper pod that's reused across operations (see pvcHelper.go).
 func (v *pvc) inContainer(ctx context.Context, fn containerCb) (interface{}, error) {
 	mountingPod, volumeName, err := v.getFirstMountingPod(ctx)
 	if err != nil {
@@ -132,19 +149,18 @@ func (v *pvc) inContainer(ctx context.Context, fn containerCb) (interface{}, err
 	var cleanup func()
 	if mountingPod == nil {
 		mountpoint = "/mnt"
-		tempPod, err := createContainer(ctx, v.podi, v.Name(), mountpoint)
+		helperPod, err := v.getHelperPod(ctx, mountpoint)
 		if err != nil {
 			return nil, err
 		}
+		// Keep the lease alive while the operation's in progress, then let it expire unless
+		// another operation renews it first.
+		stopRenewing := v.renewHelperLease(ctx)
 		cleanup = func() {
-			// Use a background context to ensure deletion happens even if context was cancelled.
-			activity.Record(ctx, "Deleted temporary pod %v: %v", tempPod, tempPod.delete(context.Background()))
+			stopRenewing()
+			v.scheduleHelperExpiry()
 		}
-		if err := tempPod.waitOnCreation(ctx); err != nil {
-			cleanup()
-			return nil, err
-		}
-		execContainer.pod = tempPod.pod
+		execContainer.pod = helperPod.pod
 	} else {
 		mount := v.getMountInfo(mountingPod, volumeName)
 		execContainer.pod = mount.pod
@@ -254,10 +270,46 @@ func (v *pvc) VolumeDelete(ctx context.Context, path string) (bool, error) {
 	return true, nil
 }
 
+func (v *pvc) VolumeMkdir(ctx context.Context, path string) error {
+	_, err := v.exec(ctx, func(base string) []string {
+		return []string{"mkdir", base + path}
+	}, nil)
+	return err
+}
+
+func (v *pvc) VolumeRename(ctx context.Context, oldPath string, newPath string) error {
+	_, err := v.exec(ctx, func(base string) []string {
+		return volume.RenameCmdPOSIX(base+oldPath, base+newPath)
+	}, nil)
+	if exerr, ok := err.(k8exec.ExitError); ok {
+		if errno, ok := volume.RenameErrno(exerr.ExitStatus()); ok {
+			return fmt.Errorf("could not move %v to %v: %w", oldPath, newPath, errno)
+		}
+	}
+	return err
+}
+
 const pvcDescription = `
-This is a Kubernetes persistent volume claim. We create a temporary Kubernetes
-pod whenever Wash invokes a currently uncached List/Read/Stream/Write action on
-it or one of its children. For List, we run 'find -exec stat' on the pod and
-parse its output. For Read, we run 'cat' and return its output. For Stream, we
-run 'tail -f' and stream its output.
+This is a Kubernetes persistent volume claim. Whenever Wash invokes a currently
+uncached List/Read/Stream/Write action on it or one of its children, we run a
+command in a pod that mounts it. For List, we run 'find -exec stat' on the pod
+and parse its output. For Read, we run 'cat' and return its output. For Stream,
+we run 'tail -f' and stream its output. For Write and creating files, we run
+'cp /dev/stdin' with the content. For creating directories, we run 'mkdir',
+and for moving files and directories we run 'mv' after checking that it won't
+move them into an existing directory.
+
+If a running pod already mounts the claim, we use it. Otherwise we create a
+helper pod labelled app.kubernetes.io/managed-by=wash that's owned by a lease
+named wash-pvc-<claim> (long claim names are shortened). Operations renew the
+lease so the helper is reused; once the lease expires, it's deleted and
+Kubernetes garbage collects the pod. Expired leases left behind by earlier Wash
+sessions are reaped on startup. The helper's image and lease TTL can be
+configured with
+
+kubernetes:
+  pvc_helper_image: <image>
+  pvc_helper_ttl: 5m
+
+in Wash's config file.
 `
@@ -0,0 +1,204 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/puppetlabs/wash/activity"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8s "k8s.io/client-go/kubernetes"
+	typedcoordinationv1 "k8s.io/client-go/kubernetes/typed/coordination/v1"
+)
+
+// Helper pods that mount a PVC are owned by a lease named after the PVC. Every operation that
+// uses the helper renews the lease, so the same pod is reused across operations. Once the lease
+// expires it's deleted (either by this process or when the plugin next starts), and Kubernetes
+// garbage collects the pod along with it.
+
+// holderIdentity identifies this Wash process as the holder of leases it renews.
+var holderIdentity = func() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("wash-%v-%v", hostname, os.Getpid())
+}()
+
+func helperLeaseName(volumeClaim string) string {
+	return "wash-pvc-" + helperID(volumeClaim)
+}
+
+func leaseExpired(lease *coordinationv1.Lease) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	ttl := time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	return time.Since(lease.Spec.RenewTime.Time) > ttl
+}
+
+func deleteLease(ctx context.Context, leasei typedcoordinationv1.LeaseInterface, name string) error {
+	// Background propagation lets the garbage collector delete the helper pod for us.
+	propagation := metav1.DeletePropagationBackground
+	err := leasei.Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// acquireHelperLease creates or renews the lease for the PVC's helper pod.
+func (v *pvc) acquireHelperLease(ctx context.Context) (*coordinationv1.Lease, error) {
+	leasei := v.client.CoordinationV1().Leases(v.namespace)
+	name := helperLeaseName(v.Name())
+	ttl := int32(v.opts.pvcHelperTTL.Seconds())
+
+	for attempt := 0; attempt < 2; attempt++ {
+		now := metav1.NewMicroTime(time.Now())
+		lease, err := leasei.Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			if leaseExpired(lease) {
+				activity.Record(ctx, "Helper lease %v expired, replacing it", name)
+				if err := deleteLease(ctx, leasei, name); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			holder := holderIdentity
+			lease.Spec.HolderIdentity = &holder
+			lease.Spec.LeaseDurationSeconds = &ttl
+			lease.Spec.RenewTime = &now
+			lease, err = leasei.Update(ctx, lease, metav1.UpdateOptions{})
+			if k8serrors.IsConflict(err) {
+				// Someone else renewed it at the same time; try again.
+				continue
+			}
+			return lease, err
+		} else if !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		holder := holderIdentity
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Labels:      helperLabels(v.Name()),
+				Annotations: helperAnnotations(v.Name()),
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &holder,
+				LeaseDurationSeconds: &ttl,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		lease, err = leasei.Create(ctx, lease, metav1.CreateOptions{})
+		if k8serrors.IsAlreadyExists(err) {
+			continue
+		}
+		return lease, err
+	}
+	return nil, fmt.Errorf("unable to acquire helper lease %v due to concurrent updates", name)
+}
+
+// renewHelperLease periodically renews the lease until the returned function is called. It's
+// used for operations, like streaming, that can outlive the lease's TTL.
+func (v *pvc) renewHelperLease(ctx context.Context) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(v.opts.pvcHelperTTL / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if _, err := v.acquireHelperLease(context.Background()); err != nil {
+					activity.Record(ctx, "Unable to renew helper lease for %v: %v", v.Name(), err)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// scheduleHelperExpiry deletes the helper lease if it hasn't been renewed by the time it expires.
+func (v *pvc) scheduleHelperExpiry() {
+	leasei := v.client.CoordinationV1().Leases(v.namespace)
+	name := helperLeaseName(v.Name())
+	// Wait slightly longer than the TTL so that a lease that was just renewed isn't expired.
+	time.AfterFunc(v.opts.pvcHelperTTL+time.Second, func() {
+		ctx := context.Background()
+		lease, err := leasei.Get(ctx, name, metav1.GetOptions{})
+		if err != nil || !leaseExpired(lease) {
+			return
+		}
+		activity.Record(ctx, "Deleting expired helper lease %v/%v: %v", v.namespace, name, deleteLease(ctx, leasei, name))
+	})
+}
+
+// getHelperPod returns a running helper pod for the PVC, creating one if necessary.
+func (v *pvc) getHelperPod(ctx context.Context, mountpoint string) (*tempContainer, error) {
+	lease, err := v.acquireHelperLease(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := labels.SelectorFromSet(helperLabels(v.Name())).String()
+	pods, err := v.podi.List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp != nil || !ownedBy(pod, lease) {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodPending || pod.Status.Phase == corev1.PodRunning {
+			activity.Record(ctx, "Reusing helper pod %v for %v", pod.Name, v.Name())
+			helper := &tempContainer{pod: pod, podi: v.podi}
+			return helper, helper.waitOnCreation(ctx)
+		}
+	}
+
+	helper, err := createContainer(ctx, v.podi, v.Name(), mountpoint, v.opts.pvcHelperImage, lease)
+	if err != nil {
+		return nil, err
+	}
+	activity.Record(ctx, "Created helper pod %v for %v", helper.pod.Name, v.Name())
+	return &helper, helper.waitOnCreation(ctx)
+}
+
+func ownedBy(pod *corev1.Pod, lease *coordinationv1.Lease) bool {
+	for _, ref := range pod.OwnerReferences {
+		if ref.UID == lease.UID {
+			return true
+		}
+	}
+	return false
+}
+
+// reapHelpers deletes expired helper leases (and by extension their pods) left behind by
+// Wash processes that exited before they could clean up.
+func reapHelpers(ctx context.Context, client *k8s.Clientset) error {
+	selector := labels.SelectorFromSet(map[string]string{managedByLabel: managedByWash}).String()
+	leases, err := client.CoordinationV1().Leases(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	for i := range leases.Items {
+		lease := &leases.Items[i]
+		if !leaseExpired(lease) {
+			continue
+		}
+		leasei := client.CoordinationV1().Leases(lease.Namespace)
+		err := deleteLease(ctx, leasei, lease.Name)
+		activity.Record(ctx, "Reaped helper lease %v/%v: %v", lease.Namespace, lease.Name, err)
+	}
+	return nil
+}
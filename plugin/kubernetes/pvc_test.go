@@ -0,0 +1,61 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newMountingPod(name string, claim string, labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{{
+				Name: "data",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestGetFirstMountingPod_SkipsHelperPods(t *testing.T) {
+	client := fake.NewSimpleClientset(newMountingPod("wash-pvc-abc", "claim", helperLabels("claim")))
+	v := &pvc{EntryBase: plugin.NewEntry("claim"), podi: client.CoreV1().Pods("default")}
+
+	// Only the helper mounts the claim, so it has to be used through its lease.
+	pod, _, err := v.getFirstMountingPod(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, pod)
+
+	_, err = client.CoreV1().Pods("default").Create(context.Background(), newMountingPod("app", "claim", nil), metav1.CreateOptions{})
+	require.NoError(t, err)
+	pod, volumeName, err := v.getFirstMountingPod(context.Background())
+	require.NoError(t, err)
+	if assert.NotNil(t, pod) {
+		assert.Equal(t, "app", pod.Name)
+		assert.Equal(t, "data", volumeName)
+	}
+}
+
+func TestHelperID(t *testing.T) {
+	assert.Equal(t, "claim", helperID("claim"))
+	assert.Equal(t, "wash-pvc-claim", helperLeaseName("claim"))
+
+	long := strings.Repeat("a", 200)
+	id := helperID(long)
+	assert.Empty(t, validation.IsValidLabelValue(id))
+	assert.NotEqual(t, id, helperID(strings.Repeat("a", 199)+"b"))
+	assert.Empty(t, validation.IsDNS1123Subdomain(helperLeaseName(long)))
+	assert.Equal(t, long, helperAnnotations(long)[pvcAnnotation])
+}
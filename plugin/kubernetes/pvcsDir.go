@@ -14,6 +14,7 @@ type pvcsDir struct {
 	client *k8s.Clientset
 	config *rest.Config
 	ns     string
+	opts   options
 }
 
 func newPVCSDir(ns *namespace) *pvcsDir {
@@ -23,6 +24,7 @@ func newPVCSDir(ns *namespace) *pvcsDir {
 	pv.client = ns.client
 	pv.config = ns.config
 	pv.ns = ns.Name()
+	pv.opts = ns.opts
 	return pv
 }
 
@@ -46,7 +48,7 @@ func (pv *pvcsDir) List(ctx context.Context) ([]plugin.Entry, error) {
 	}
 	entries := make([]plugin.Entry, len(pvcList.Items))
 	for i, p := range pvcList.Items {
-		entries[i] = newPVC(pvcI, pv.client, pv.config, pv.ns, &p)
+		entries[i] = newPVC(pvcI, pv.client, pv.config, pv.ns, pv.opts, &p)
 	}
 	return entries, nil
 }
@@ -5,11 +5,15 @@ package kubernetes
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/puppetlabs/wash/activity"
 	"github.com/puppetlabs/wash/plugin"
 	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	k8s "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
@@ -21,9 +25,151 @@ import (
 // Root of the Kubernetes plugin
 type Root struct {
 	plugin.EntryBase
+	opts options
+	// The root's List isn't cached so that it picks up changes to ~/.kube/config. Whether
+	// each context's cluster is reachable is remembered instead, so that listing the root
+	// doesn't probe every cluster every time.
+	reachabilityMux sync.Mutex
+	reachability    map[string]reachability
 }
 
-func createContext(raw clientcmdapi.Config, name string, access clientcmd.ConfigAccess) (plugin.Entry, error) {
+type reachability struct {
+	err       error
+	checkedAt time.Time
+}
+
+// reachabilityTTL is how long a context's reachability is remembered.
+const reachabilityTTL = time.Minute
+
+// allDirName is the name of the directory that merges resources across contexts.
+const allDirName = "_all"
+
+// options holds plugin-wide configuration from Wash's config file. It's passed down
+// to the entries whose behavior it affects.
+type options struct {
+	// logSince limits streamed container logs to those newer than the duration.
+	logSince time.Duration
+	// logTimestamps prefixes each line of container logs with its RFC3339 timestamp.
+	logTimestamps bool
+	// debugImage is the image used for ephemeral debug containers.
+	debugImage string
+	// pvcHelperImage is the image used for helper pods that mount PVCs.
+	pvcHelperImage string
+	// pvcHelperTTL is how long a helper pod's lease lasts without being renewed.
+	pvcHelperTTL time.Duration
+	// contextTimeout bounds how long we wait for a context's cluster to respond.
+	contextTimeout time.Duration
+	// allPodsSelector is the label selector used to filter pods in _all/pods.
+	allPodsSelector string
+}
+
+func defaultOptions() options {
+	return options{
+		debugImage:     "busybox",
+		pvcHelperImage: "busybox",
+		pvcHelperTTL:   5 * time.Minute,
+		contextTimeout: 10 * time.Second,
+	}
+}
+
+// Options are the Kubernetes plugin's configured options. Other plugins, like GCP, use
+// them to create contexts that behave like the plugin's own.
+type Options struct {
+	opts options
+}
+
+// ParseOptions parses a config section with the Kubernetes plugin's options for other plugins
+// that create Kubernetes contexts. key is the section's key, like gcp.gke, which is used in
+// errors. Options that aren't set keep their defaults, so a nil cfg returns the default options.
+func ParseOptions(key string, cfg map[string]interface{}) (Options, error) {
+	opts, err := parseOptions(key, cfg)
+	if err != nil {
+		return Options{}, err
+	}
+	return Options{opts: opts}, nil
+}
+
+func parseOptions(key string, cfg map[string]interface{}) (options, error) {
+	opts := defaultOptions()
+
+	if sinceI, ok := cfg["log_since"]; ok {
+		since, ok := sinceI.(string)
+		if !ok {
+			return options{}, fmt.Errorf("%v.log_since config must be a duration string, not %v", key, sinceI)
+		}
+		dur, err := time.ParseDuration(since)
+		if err != nil {
+			return options{}, fmt.Errorf("%v.log_since config must be a duration string: %v", key, err)
+		}
+		opts.logSince = dur
+	}
+
+	if timestampsI, ok := cfg["log_timestamps"]; ok {
+		timestamps, ok := timestampsI.(bool)
+		if !ok {
+			return options{}, fmt.Errorf("%v.log_timestamps config must be a boolean, not %v", key, timestampsI)
+		}
+		opts.logTimestamps = timestamps
+	}
+
+	if imageI, ok := cfg["debug_image"]; ok {
+		image, ok := imageI.(string)
+		if !ok {
+			return options{}, fmt.Errorf("%v.debug_image config must be a string, not %v", key, imageI)
+		}
+		opts.debugImage = image
+	}
+
+	if imageI, ok := cfg["pvc_helper_image"]; ok {
+		image, ok := imageI.(string)
+		if !ok {
+			return options{}, fmt.Errorf("%v.pvc_helper_image config must be a string, not %v", key, imageI)
+		}
+		opts.pvcHelperImage = image
+	}
+
+	if ttlI, ok := cfg["pvc_helper_ttl"]; ok {
+		ttl, ok := ttlI.(string)
+		if !ok {
+			return options{}, fmt.Errorf("%v.pvc_helper_ttl config must be a duration string, not %v", key, ttlI)
+		}
+		dur, err := time.ParseDuration(ttl)
+		if err != nil {
+			return options{}, fmt.Errorf("%v.pvc_helper_ttl config must be a duration string: %v", key, err)
+		}
+		if dur < 10*time.Second {
+			return options{}, fmt.Errorf("%v.pvc_helper_ttl config must be at least 10s, not %v", key, dur)
+		}
+		opts.pvcHelperTTL = dur
+	}
+
+	if timeoutI, ok := cfg["context_timeout"]; ok {
+		timeout, ok := timeoutI.(string)
+		if !ok {
+			return options{}, fmt.Errorf("%v.context_timeout config must be a duration string, not %v", key, timeoutI)
+		}
+		dur, err := time.ParseDuration(timeout)
+		if err != nil {
+			return options{}, fmt.Errorf("%v.context_timeout config must be a duration string: %v", key, err)
+		}
+		opts.contextTimeout = dur
+	}
+
+	if selectorI, ok := cfg["all_pods_selector"]; ok {
+		selector, ok := selectorI.(string)
+		if !ok {
+			return options{}, fmt.Errorf("%v.all_pods_selector config must be a string, not %v", key, selectorI)
+		}
+		if _, err := labels.Parse(selector); err != nil {
+			return options{}, fmt.Errorf("%v.all_pods_selector config must be a label selector: %v", key, err)
+		}
+		opts.allPodsSelector = selector
+	}
+
+	return opts, nil
+}
+
+func createContext(raw clientcmdapi.Config, name string, access clientcmd.ConfigAccess, opts options) (*k8context, error) {
 	config := clientcmd.NewNonInteractiveClientConfig(raw, name, &clientcmd.ConfigOverrides{}, access)
 	cfg, err := config.ClientConfig()
 	if err != nil {
@@ -37,17 +183,62 @@ func createContext(raw clientcmdapi.Config, name string, access clientcmd.Config
 	if err != nil {
 		return nil, err
 	}
-	return newK8Context(name, clientset, cfg, defaultns), nil
+	return newK8Context(name, clientset, cfg, defaultns, opts), nil
 }
 
 // Init for root
-func (r *Root) Init(map[string]interface{}) error {
+func (r *Root) Init(cfg map[string]interface{}) error {
 	r.EntryBase = plugin.NewEntry("kubernetes")
 	r.DisableDefaultCaching()
+	r.reachability = make(map[string]reachability)
 
+	opts, err := parseOptions("kubernetes", cfg)
+	if err != nil {
+		return err
+	}
+	r.opts = opts
+
+	go r.reapHelpers()
 	return nil
 }
 
+// reapHelpers cleans up PVC helper pods whose leases expired, e.g. because a previous Wash
+// session exited before it could clean them up. It runs in the background so that
+// unreachable clusters don't delay startup.
+func (r *Root) reapHelpers() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+	raw, err := config.RawConfig()
+	if err != nil {
+		activity.Record(ctx, "Unable to load Kubernetes config to reap helper pods: %v", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for name := range raw.Contexts {
+		clientConfig := clientcmd.NewNonInteractiveClientConfig(raw, name, &clientcmd.ConfigOverrides{}, config.ConfigAccess())
+		cfg, err := clientConfig.ClientConfig()
+		if err != nil {
+			continue
+		}
+		clientset, err := k8s.NewForConfig(cfg)
+		if err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			if err := reapHelpers(ctx, clientset); err != nil {
+				activity.Record(ctx, "Unable to reap helper pods in context %v: %v", name, err)
+			}
+		}(name)
+	}
+	wg.Wait()
+}
+
 // Schema returns the root's schema
 func (r *Root) Schema() *plugin.EntrySchema {
 	return plugin.
@@ -59,6 +250,7 @@ func (r *Root) Schema() *plugin.EntrySchema {
 // ChildSchemas returns the root's child schemas
 func (r *Root) ChildSchemas() []*plugin.EntrySchema {
 	return []*plugin.EntrySchema{
+		(&allDir{}).Schema(),
 		(&k8context{}).Schema(),
 	}
 }
@@ -71,7 +263,8 @@ func (r *Root) WrappedTypes() plugin.SchemaMap {
 	}
 }
 
-// List returns available contexts.
+// List returns available contexts. Contexts are loaded in parallel; those whose clusters
+// don't respond within the context timeout are marked inaccessible.
 func (r *Root) List(ctx context.Context) ([]plugin.Entry, error) {
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
 	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
@@ -81,22 +274,90 @@ func (r *Root) List(ctx context.Context) ([]plugin.Entry, error) {
 		return nil, err
 	}
 
-	contexts := make([]plugin.Entry, 0)
+	var mux sync.Mutex
+	var wg sync.WaitGroup
+	contexts := make([]*k8context, 0, len(raw.Contexts))
 	for name := range raw.Contexts {
-		ctx, err := createContext(raw, name, config.ConfigAccess())
-		if err != nil {
-			activity.Warnf(context.Background(), "loading context %v failed: %+v", name, err)
-			continue
-		}
-		contexts = append(contexts, ctx)
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			k8ctx, err := createContext(raw, name, config.ConfigAccess(), r.opts)
+			if err != nil {
+				activity.Warnf(context.Background(), "loading context %v failed: %+v", name, err)
+				return
+			}
+			if err := r.checkReachable(ctx, name, k8ctx); err != nil {
+				k8ctx.MarkInaccessible(ctx, err)
+			}
+
+			mux.Lock()
+			contexts = append(contexts, k8ctx)
+			mux.Unlock()
+		}(name)
+	}
+	wg.Wait()
+
+	entries := make([]plugin.Entry, 0, len(contexts)+1)
+	if _, ok := raw.Contexts[allDirName]; ok {
+		activity.Warnf(ctx, "Omitting kubernetes/%v because a context has the same name", allDirName)
+	} else {
+		entries = append(entries, newAllDir(contexts, r.opts))
 	}
+	for _, k8ctx := range contexts {
+		entries = append(entries, k8ctx)
+	}
+	return entries, nil
+}
 
-	return contexts, nil
+// checkReachable returns whether the named context's cluster was reachable the last time it
+// was checked, checking it again if that was more than reachabilityTTL ago.
+func (r *Root) checkReachable(ctx context.Context, name string, k8ctx *k8context) error {
+	r.reachabilityMux.Lock()
+	cached, ok := r.reachability[name]
+	r.reachabilityMux.Unlock()
+	if ok && time.Since(cached.checkedAt) < reachabilityTTL {
+		return cached.err
+	}
+
+	err := k8ctx.checkReachable(ctx)
+	if ctx.Err() != nil {
+		// The check was cancelled, so it doesn't tell us anything about the cluster.
+		return err
+	}
+	r.reachabilityMux.Lock()
+	r.reachability[name] = reachability{err: err, checkedAt: time.Now()}
+	r.reachabilityMux.Unlock()
+	return err
 }
 
 const rootDescription = `
 This is the Kubernetes plugin root. It lets you interact with Kubernetes resources
 like pods and persistent volume claims.
 
-Kubernetes contexts are extracted from ~/.kube/config.
+Kubernetes contexts are extracted from ~/.kube/config. They're loaded in
+parallel; contexts whose clusters don't respond within 10 seconds are marked
+inaccessible, which is rechecked at most once a minute. The _all directory
+merges resources across all accessible contexts, e.g. 'find kubernetes/_all/pods'
+searches pods in every cluster. It's omitted if one of your contexts is named
+_all.
+Both can be configured with
+
+kubernetes:
+  context_timeout: 5s
+  all_pods_selector: app=nginx
+
+Streaming a container's log starts with its last 10 lines. You can instead
+start from a point in time and prefix each log line with its timestamp by
+adding
+
+kubernetes:
+  log_since: 1h
+  log_timestamps: true
+
+to Wash's config file.
+
+Pods support port forwarding via the forward action. Their debug signal attaches
+an ephemeral container for troubleshooting; its image defaults to busybox and
+can be set with the debug_image config key. See the description of persistent
+volume claims for how to configure the pods used to access them.
 `
@@ -2,11 +2,14 @@ package kubernetes
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/puppetlabs/wash/activity"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,23 +17,64 @@ import (
 	typedv1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
+// Labels identifying pods and leases that Wash creates to access persistent volume claims.
+// Label values are limited to 63 characters but claim names aren't, so pvcLabel holds the
+// claim's helperID and pvcAnnotation holds its full name.
+const (
+	managedByLabel = "app.kubernetes.io/managed-by"
+	managedByWash  = "wash"
+	pvcLabel       = "wash.puppet.com/pvc"
+	pvcAnnotation  = "wash.puppet.com/pvc"
+)
+
+// maxLabelValueLength is the longest value Kubernetes allows for a label.
+const maxLabelValueLength = 63
+
+// helperID identifies a claim's helper pod and lease. It's the claim's name if that's a valid
+// label value, otherwise it's the start of the name followed by a hash of the whole name.
+func helperID(volumeClaim string) string {
+	if len(volumeClaim) <= maxLabelValueLength {
+		return volumeClaim
+	}
+	sum := sha256.Sum256([]byte(volumeClaim))
+	hash := hex.EncodeToString(sum[:])[:10]
+	return volumeClaim[:maxLabelValueLength-len(hash)-1] + "-" + hash
+}
+
+// helperVolumeName is the name of the claim's volume in helper pods. Volume names are limited
+// to 63 characters, so it isn't the claim's name.
+const helperVolumeName = "pvc"
+
 // A temporary container with tools for handling cleanup.
 type tempContainer struct {
 	pod  *corev1.Pod
 	podi typedv1.PodInterface
 }
 
-// Create a container that mounts a pvc to a default mountpoint and waits for 7 days.
-func createContainer(ctx context.Context, podi typedv1.PodInterface, volumeClaim, mountpoint string) (c tempContainer, err error) {
+// Create a container that mounts a pvc to a default mountpoint and waits for 7 days. The pod is
+// owned by the lease, so Kubernetes garbage collects it when the lease is deleted.
+func createContainer(ctx context.Context, podi typedv1.PodInterface, volumeClaim, mountpoint, image string, lease *coordinationv1.Lease) (c tempContainer, err error) {
+	isController := true
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
-			GenerateName: "wash",
+			GenerateName: "wash-pvc-",
+			Labels:       helperLabels(volumeClaim),
+			Annotations:  helperAnnotations(volumeClaim),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: coordinationv1.SchemeGroupVersion.String(),
+					Kind:       "Lease",
+					Name:       lease.Name,
+					UID:        lease.UID,
+					Controller: &isController,
+				},
+			},
 		},
 		Spec: corev1.PodSpec{
 			Containers: []corev1.Container{
 				{
-					Name:  "busybox",
-					Image: "busybox",
+					Name:  "helper",
+					Image: image,
 					Args:  []string{"sleep", "604800"},
 					Resources: corev1.ResourceRequirements{
 						Requests: corev1.ResourceList{
@@ -40,7 +84,7 @@ func createContainer(ctx context.Context, podi typedv1.PodInterface, volumeClaim
 					},
 					VolumeMounts: []corev1.VolumeMount{
 						{
-							Name:      volumeClaim,
+							Name:      helperVolumeName,
 							MountPath: mountpoint,
 							ReadOnly:  true,
 						},
@@ -50,7 +94,7 @@ func createContainer(ctx context.Context, podi typedv1.PodInterface, volumeClaim
 			RestartPolicy: corev1.RestartPolicyNever,
 			Volumes: []corev1.Volume{
 				{
-					Name: volumeClaim,
+					Name: helperVolumeName,
 					VolumeSource: corev1.VolumeSource{
 						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
 							ClaimName: volumeClaim,
@@ -70,6 +114,10 @@ func createContainer(ctx context.Context, podi typedv1.PodInterface, volumeClaim
 var errPodTerminated = errors.New("Pod terminated unexpectedly")
 
 func (c *tempContainer) waitOnCreation(ctx context.Context) error {
+	if c.pod.Status.Phase == corev1.PodRunning {
+		return nil
+	}
+
 	watchOpts := metav1.ListOptions{FieldSelector: "metadata.name=" + c.pod.Name}
 	watcher, err := c.podi.Watch(ctx, watchOpts)
 	if err != nil {
@@ -85,7 +133,7 @@ func (c *tempContainer) waitOnCreation(ctx context.Context) error {
 				return fmt.Errorf("Channel error waiting for pod %v: %v", c, e)
 			}
 			switch e.Type {
-			case watch.Modified:
+			case watch.Added, watch.Modified:
 				switch e.Object.(*corev1.Pod).Status.Phase {
 				case corev1.PodRunning:
 					// Success, we have a running pod.
@@ -106,11 +154,19 @@ func (c *tempContainer) waitOnCreation(ctx context.Context) error {
 	}
 }
 
-func (c *tempContainer) delete(ctx context.Context) error {
-	var deleteImmediately int64 = 0
-	return c.podi.Delete(ctx, c.pod.Name, metav1.DeleteOptions{GracePeriodSeconds: &deleteImmediately})
-}
-
 func (c *tempContainer) String() string {
 	return c.pod.String()
 }
+
+func helperLabels(volumeClaim string) map[string]string {
+	return map[string]string{
+		managedByLabel: managedByWash,
+		pvcLabel:       helperID(volumeClaim),
+	}
+}
+
+func helperAnnotations(volumeClaim string) map[string]string {
+	return map[string]string{
+		pvcAnnotation: volumeClaim,
+	}
+}
@@ -0,0 +1,71 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Link is an entry that links to another entry, similar to a symbolic link. It lets
+// plugins cross-reference related resources (like a pod's persistent volume claims)
+// without duplicating them. The filesystem presents links as symlinks.
+type Link struct {
+	EntryBase
+	target string
+}
+
+// NewLink creates a link to the entry at target. If target starts with a '/', then
+// it's the linked entry's ID (e.g. "/docker/volumes/foo"). Otherwise it's relative to
+// the link's parent (e.g. "../../volumes/foo").
+func NewLink(name string, target string) *Link {
+	l := &Link{EntryBase: NewEntry(name), target: target}
+	l.Attributes().SetMode(os.ModeSymlink | 0777)
+	return l
+}
+
+// Schema returns the link's schema.
+func (l *Link) Schema() *EntrySchema {
+	return NewEntrySchema(l, "link").SetDescription(linkDescription)
+}
+
+// LinkTarget returns the path of the linked entry.
+func (l *Link) LinkTarget() string {
+	return l.target
+}
+
+// LinkTarget returns the ID of the entry that the link links to.
+func LinkTarget(l Linkable) string {
+	target := l.LinkTarget()
+	if path.IsAbs(target) {
+		return path.Clean(target)
+	}
+	return path.Join(path.Dir(ID(l)), target)
+}
+
+// RelativeLinkTarget returns the path of the entry that the link links to, relative to
+// the link's parent. It's what reading the link in the filesystem returns.
+func RelativeLinkTarget(l Linkable) string {
+	rel, err := filepath.Rel(path.Dir(ID(l)), LinkTarget(l))
+	if err != nil {
+		// Both paths are absolute, so this shouldn't happen.
+		panic(err)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// FindLinkTarget returns the entry that the link links to.
+func FindLinkTarget(ctx context.Context, registry *Registry, l Linkable) (Entry, error) {
+	target := strings.Trim(LinkTarget(l), "/")
+	if target == "" {
+		return registry, nil
+	}
+	return FindEntry(ctx, registry, strings.Split(target, "/"))
+}
+
+const linkDescription = `
+This is a link to another entry. The filesystem presents it as a symbolic
+link, so reading the link shows the linked entry's path. Use 'find -L' to
+include the linked entries in its results.
+`
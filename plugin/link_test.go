@@ -0,0 +1,76 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/puppetlabs/wash/datastore"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LinkTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LinkTestSuite) SetupTest() {
+	SetTestCache(datastore.NewMemCache())
+}
+
+func (suite *LinkTestSuite) TearDownTest() {
+	UnsetTestCache()
+}
+
+func (suite *LinkTestSuite) TestNewLink() {
+	l := NewLink("foo", "/mine/bar")
+	suite.Equal(os.ModeSymlink|0777, l.Attributes().Mode())
+	suite.Empty(SupportedActionsOf(l))
+}
+
+func (suite *LinkTestSuite) TestLinkTarget() {
+	for target, expected := range map[string][]string{
+		"/mine/bar":      {"/mine/bar", "../bar"},
+		"/mine/dir/bar/": {"/mine/dir/bar", "bar"},
+		"/other/bar":     {"/other/bar", "../../other/bar"},
+		"bar":            {"/mine/dir/bar", "bar"},
+		"../../bar":      {"/bar", "../../bar"},
+	} {
+		l := NewLink("foo", target)
+		l.SetTestID("/mine/dir/foo")
+		suite.Equal(expected[0], LinkTarget(l), target)
+		suite.Equal(expected[1], RelativeLinkTarget(l), target)
+	}
+}
+
+func (suite *LinkTestSuite) TestFindLinkTarget() {
+	bar := newMockEntry("bar")
+	dir := &mockParent{EntryBase: NewEntry("dir"), entries: []Entry{bar}}
+	root := &mockRoot{EntryBase: NewEntry("mine")}
+	root.On("Init", mock.Anything).Return(nil)
+	root.On("List", mock.Anything).Return([]Entry{dir}, nil)
+
+	registry := NewRegistry()
+	suite.NoError(registry.RegisterPlugin(root, nil))
+
+	l := NewLink("foo", "bar")
+	l.SetTestID("/mine/dir/foo")
+	target, err := FindLinkTarget(context.Background(), registry, l)
+	if suite.NoError(err) {
+		suite.Equal(bar, target)
+	}
+
+	l = NewLink("foo", "/")
+	target, err = FindLinkTarget(context.Background(), registry, l)
+	if suite.NoError(err) {
+		suite.Equal(registry, target)
+	}
+
+	l = NewLink("foo", "/mine/missing")
+	_, err = FindLinkTarget(context.Background(), registry, l)
+	suite.Error(err)
+}
+
+func TestLink(t *testing.T) {
+	suite.Run(t, new(LinkTestSuite))
+}
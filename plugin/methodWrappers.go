@@ -2,8 +2,11 @@ package plugin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +29,11 @@ func IsInvalidInputErr(err error) bool {
 	return ok
 }
 
+// ErrCrossParentRename indicates that an entry can't be moved to the requested
+// parent. Filesystems report it as EXDEV so that tools like mv fall back to
+// copying the entry.
+var ErrCrossParentRename = errors.New("the entry cannot be moved to that parent")
+
 // This file contains all of the plugin.<Method> wrappers. You should
 // invoke plugin.<Method> instead of e.<Method> because plugin.<Method>
 // could contain additional, plugin-agnostic code required to get e.<Method>
@@ -93,6 +101,12 @@ func ID(e Entry) string {
 	return e.eb().id
 }
 
+// TTLOf returns how long the result of the entry's op is cached. It's negative if the
+// op isn't cached.
+func TTLOf(e Entry, op defaultOpCode) time.Duration {
+	return e.eb().ttl[op]
+}
+
 // Attributes returns the entry's attributes.
 func Attributes(e Entry) EntryAttributes {
 	return e.eb().attributes
@@ -151,10 +165,16 @@ func Read(ctx context.Context, e Entry, size int64, offset int64) (data []byte,
 	} else if ReadAction().signature(e) == BlockReadableSignature {
 		activity.Warnf(ctx, "size attribute not set for block-readable entry %v", e.eb().id)
 	}
-	content, contentErr := cachedRead(ctx, e)
-	if contentErr != nil {
-		err = contentErr
-		return
+	var content entryContent
+	if ReadAction().signature(e) == SequentialReadableSignature {
+		// Sequential content isn't cached, so it's read from a new stream.
+		content = sequentialEntryContent{entry: e.(SequentialReadable)}
+	} else {
+		var contentErr error
+		if content, contentErr = cachedRead(ctx, e); contentErr != nil {
+			err = contentErr
+			return
+		}
 	}
 	data, readErr := content.read(ctx, size, offset)
 	if readErr != nil {
@@ -176,6 +196,11 @@ func Size(ctx context.Context, e Entry) (uint64, error) {
 		return 0, nil
 	}
 
+	if ReadAction().signature(e) == SequentialReadableSignature {
+		// Getting the size would mean reading all of the content.
+		return 0, nil
+	}
+
 	data, err := cachedRead(ctx, e)
 	if err != nil {
 		return 0, err
@@ -201,6 +226,11 @@ func Exec(ctx context.Context, e Execable, cmd string, args []string, opts ExecO
 	return e.Exec(ctx, cmd, args, opts)
 }
 
+// ReadStream returns a stream of the entry's content. Its content isn't cached.
+func ReadStream(ctx context.Context, s SequentialReadable) (io.ReadCloser, error) {
+	return s.ReadStream(ctx)
+}
+
 // Stream streams the entry's content for updates.
 func Stream(ctx context.Context, s Streamable) (io.ReadCloser, error) {
 	return s.Stream(ctx)
@@ -211,6 +241,66 @@ func Write(ctx context.Context, a Writable, b []byte) error {
 	return a.Write(ctx, b)
 }
 
+// WriteFrom sends size bytes read from r to the entry. StreamWritable entries
+// get the data as it's read, other entries get all of it at once, so it's read
+// into memory first.
+func WriteFrom(ctx context.Context, a Writable, r io.Reader, size int64) error {
+	s, ok := a.(StreamWritable)
+	if !ok {
+		data, err := ioutil.ReadAll(io.LimitReader(r, size))
+		if err != nil {
+			return err
+		}
+		return a.Write(ctx, data)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	w, err := s.WriteStream(ctx, size)
+	if err != nil {
+		return err
+	}
+	if _, err := io.CopyN(w, r, size); err != nil {
+		// Cancel first so that the write's abandoned rather than finished.
+		cancel()
+		if closeErr := w.Close(); closeErr != nil {
+			activity.Record(ctx, "Closing the abandoned write to %v errored: %v", ID(a), closeErr)
+		}
+		return err
+	}
+	return w.Close()
+}
+
+// Forward forwards the given port mappings to the entry until ctx is cancelled.
+func Forward(ctx context.Context, f Forwardable, ports []string, out io.Writer) error {
+	if len(ports) == 0 {
+		return InvalidInputErr{"at least one port mapping must be provided"}
+	}
+	for _, port := range ports {
+		if err := validatePortMapping(port); err != nil {
+			return InvalidInputErr{err.Error()}
+		}
+	}
+	return f.Forward(ctx, ports, out)
+}
+
+func validatePortMapping(mapping string) error {
+	segments := strings.Split(mapping, ":")
+	if len(segments) > 2 {
+		return fmt.Errorf("invalid port mapping %v: expected <local_port>:<remote_port> or <port>", mapping)
+	}
+	for i, segment := range segments {
+		// An empty local port means a random local port should be chosen.
+		if i == 0 && segment == "" && len(segments) == 2 {
+			continue
+		}
+		if port, err := strconv.ParseUint(segment, 10, 16); err != nil || port == 0 {
+			return fmt.Errorf("invalid port mapping %v: %v is not a valid port", mapping, segment)
+		}
+	}
+	return nil
+}
+
 // Signal signals the entry with the specified signal
 func Signal(ctx context.Context, s Signalable, signal string) error {
 	// Signals are case-insensitive
@@ -261,6 +351,74 @@ func Signal(ctx context.Context, s Signalable, signal string) error {
 	return nil
 }
 
+// Create creates a child of the given parent with the specified name and content.
+func Create(ctx context.Context, p Creatable, name string, content []byte) (Entry, error) {
+	if err := validateChildName(name); err != nil {
+		return nil, err
+	}
+
+	entry, err := p.Create(context.WithValue(ctx, parentID, p.eb().id), name, content)
+	if err != nil {
+		return nil, err
+	}
+	addCreatedChild(p, entry)
+	return entry, nil
+}
+
+// CreateDir creates an empty child directory of the given parent with the specified name.
+func CreateDir(ctx context.Context, p DirCreatable, name string) (Parent, error) {
+	if err := validateChildName(name); err != nil {
+		return nil, err
+	}
+
+	dir, err := p.CreateDir(context.WithValue(ctx, parentID, p.eb().id), name)
+	if err != nil {
+		return nil, err
+	}
+	addCreatedChild(p, dir)
+	return dir, nil
+}
+
+func validateChildName(name string) error {
+	if name == "" || strings.Contains(name, "/") {
+		return InvalidInputErr{fmt.Sprintf("invalid name %q: names must be non-empty and cannot contain a /", name)}
+	}
+	return nil
+}
+
+func addCreatedChild(p Parent, child Entry) {
+	setChildID(p.eb().id, child)
+	passAlongWrappedTypes(p, child)
+
+	// Clear the parent's cached list result so that the new child's included the
+	// next time it's listed, and forget that the child was missing.
+	listOpName := defaultOpCodeToNameMap[ListOp]
+	cache.Delete(opKeyRegex(listOpName, p.eb().id))
+	cache.Delete(opKeyRegex(missingOpName, child.eb().id))
+}
+
+// Rename moves the given entry to newName under newParent.
+func Rename(ctx context.Context, r Renamable, newParent Parent, newName string) (Entry, error) {
+	if err := validateChildName(newName); err != nil {
+		return nil, err
+	}
+
+	entry, err := r.Rename(context.WithValue(ctx, parentID, newParent.eb().id), newParent, newName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The entry no longer exists at its old path, so clear its cache along with the
+	// old parent's cached list result. Then treat the moved entry like a newly created
+	// child of the new parent.
+	oldParentID, _ := splitID(r.eb().id)
+	ClearCacheFor(r.eb().id, true)
+	listOpName := defaultOpCodeToNameMap[ListOp]
+	cache.Delete(opKeyRegex(listOpName, oldParentID))
+	addCreatedChild(newParent, entry)
+	return entry, nil
+}
+
 // Delete deletes the given entry.
 func Delete(ctx context.Context, d Deletable) (deleted bool, err error) {
 	deleted, err = d.Delete(ctx)
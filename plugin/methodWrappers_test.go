@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -88,6 +90,46 @@ func (m *methodWrappersTestsMockEntry) Write(ctx context.Context, p []byte) erro
 	return args.Error(0)
 }
 
+func (m *methodWrappersTestsMockEntry) Forward(ctx context.Context, ports []string, out io.Writer) error {
+	args := m.Called(ctx, ports, out)
+	return args.Error(0)
+}
+
+type methodWrappersTestsMockCreatable struct {
+	*methodWrappersTestsMockEntry
+}
+
+func (m methodWrappersTestsMockCreatable) ChildSchemas() []*EntrySchema {
+	return nil
+}
+
+func (m methodWrappersTestsMockCreatable) Create(ctx context.Context, name string, content []byte) (Entry, error) {
+	args := m.Called(ctx, name, content)
+	return args.Get(0).(Entry), args.Error(1)
+}
+
+type methodWrappersTestsMockDirCreatable struct {
+	*methodWrappersTestsMockEntry
+}
+
+func (m methodWrappersTestsMockDirCreatable) ChildSchemas() []*EntrySchema {
+	return nil
+}
+
+func (m methodWrappersTestsMockDirCreatable) CreateDir(ctx context.Context, name string) (Parent, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(Parent), args.Error(1)
+}
+
+type methodWrappersTestsMockRenamable struct {
+	*methodWrappersTestsMockEntry
+}
+
+func (m methodWrappersTestsMockRenamable) Rename(ctx context.Context, newParent Parent, newName string) (Entry, error) {
+	args := m.Called(ctx, newParent, newName)
+	return args.Get(0).(Entry), args.Error(1)
+}
+
 func newMethodWrappersTestsMockEntry(name string) *methodWrappersTestsMockEntry {
 	e := &methodWrappersTestsMockEntry{
 		EntryBase: NewEntry(name),
@@ -258,6 +300,41 @@ func (suite *MethodWrappersTestSuite) TestRead_EntryHasSizeAttribute() {
 	}
 }
 
+type methodWrappersTestsMockSequentialReadableEntry struct {
+	*methodWrappersTestsMockEntry
+}
+
+func (m *methodWrappersTestsMockSequentialReadableEntry) ReadStream(ctx context.Context) (io.ReadCloser, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (suite *MethodWrappersTestSuite) TestRead_SequentialReadableEntry() {
+	e := &methodWrappersTestsMockSequentialReadableEntry{
+		newMethodWrappersTestsMockEntry("mockEntry"),
+	}
+	e.SetTestID("/foo")
+	suite.Equal(SequentialReadableSignature, ReadAction().signature(e))
+
+	// Each read starts a new stream because the content isn't cached.
+	ctx := context.Background()
+	e.On("ReadStream", mock.Anything).Return(ioutil.NopCloser(strings.NewReader("some raw content")), nil).Once()
+	e.On("ReadStream", mock.Anything).Return(ioutil.NopCloser(strings.NewReader("some raw content")), nil).Once()
+
+	data, err := Read(ctx, e, 2, 1)
+	suite.NoError(err)
+	suite.Equal([]byte("om"), data)
+	data, err = Read(ctx, e, 100, 10)
+	suite.Equal(io.EOF, err)
+	suite.Equal([]byte("ontent"), data)
+	e.AssertExpectations(suite.T())
+
+	// Getting the size of sequential content would mean reading all of it.
+	size, err := Size(ctx, e)
+	suite.NoError(err)
+	suite.Zero(size)
+}
+
 func (suite *MethodWrappersTestSuite) TestSize() {
 	ctx := context.Background()
 
@@ -296,6 +373,55 @@ func (suite *MethodWrappersTestSuite) TestWrite() {
 	writable.AssertExpectations(suite.T())
 }
 
+type methodWrappersTestsMockStreamWritableEntry struct {
+	*methodWrappersTestsMockEntry
+}
+
+func (m *methodWrappersTestsMockStreamWritableEntry) WriteStream(ctx context.Context, size int64) (io.WriteCloser, error) {
+	args := m.Called(ctx, size)
+	return args.Get(0).(io.WriteCloser), args.Error(1)
+}
+
+// mockUpload records what's written to it, and whether its context was cancelled when it's closed.
+type mockUpload struct {
+	strings.Builder
+	ctx       context.Context
+	abandoned bool
+}
+
+func (m *mockUpload) Close() error {
+	m.abandoned = m.ctx.Err() != nil
+	return nil
+}
+
+func (suite *MethodWrappersTestSuite) TestWriteFrom() {
+	ctx := context.Background()
+
+	writable := newMethodWrappersTestsMockEntry("/mock")
+	writable.On("Write", ctx, []byte("some")).Return(nil).Once()
+	suite.NoError(WriteFrom(ctx, writable, strings.NewReader("some data"), 4))
+	writable.AssertExpectations(suite.T())
+
+	streamWritable := &methodWrappersTestsMockStreamWritableEntry{newMethodWrappersTestsMockEntry("/mock")}
+	streamWritable.SetTestID("/mock")
+	upload := &mockUpload{}
+	streamWritable.On("WriteStream", mock.Anything, int64(4)).Return(upload, nil).Once().Run(func(args mock.Arguments) {
+		upload.ctx = args.Get(0).(context.Context)
+	})
+	suite.NoError(WriteFrom(ctx, streamWritable, strings.NewReader("some data"), 4))
+	suite.Equal("some", upload.String())
+	suite.False(upload.abandoned)
+
+	// Writes that don't get all of the data are abandoned.
+	upload = &mockUpload{}
+	streamWritable.On("WriteStream", mock.Anything, int64(100)).Return(upload, nil).Once().Run(func(args mock.Arguments) {
+		upload.ctx = args.Get(0).(context.Context)
+	})
+	suite.Equal(io.EOF, WriteFrom(ctx, streamWritable, strings.NewReader("some data"), 100))
+	suite.True(upload.abandoned)
+	streamWritable.AssertExpectations(suite.T())
+}
+
 func (suite *MethodWrappersTestSuite) TestSignal_ReturnsSignalError() {
 	ctx := context.Background()
 	e := newMethodWrappersTestsMockEntry("foo")
@@ -366,6 +492,36 @@ func (suite *MethodWrappersTestSuite) TestSignal_SchemaKnown_ReturnsInvalidInput
 	suite.Regexp("invalid.*signal.*invalid_signal.*start.*stop.*linux", err)
 }
 
+func (suite *MethodWrappersTestSuite) TestForward_ReturnsInvalidInputErrForInvalidPorts() {
+	ctx := context.Background()
+	e := newMethodWrappersTestsMockEntry("foo")
+
+	invalidPorts := [][]string{
+		{},
+		{"foo"},
+		{"8080:foo"},
+		{"0"},
+		{"70000"},
+		{"8080:80:90"},
+		{"8080", ":"},
+	}
+	for _, ports := range invalidPorts {
+		err := Forward(ctx, e, ports, ioutil.Discard)
+		suite.True(IsInvalidInputErr(err), "expected an InvalidInputErr for %v, got %v", ports, err)
+	}
+	e.AssertNotCalled(suite.T(), "Forward", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *MethodWrappersTestSuite) TestForward_ForwardsValidPorts() {
+	ctx := context.Background()
+	e := newMethodWrappersTestsMockEntry("foo")
+
+	ports := []string{"8080:80", "9090", ":443"}
+	e.On("Forward", ctx, ports, ioutil.Discard).Return(nil).Once()
+	suite.NoError(Forward(ctx, e, ports, ioutil.Discard))
+	e.AssertExpectations(suite.T())
+}
+
 func (suite *MethodWrappersTestSuite) TestDelete_ReturnsDeleteError() {
 	ctx := context.Background()
 	e := newMethodWrappersTestsMockEntry("foo")
@@ -445,6 +601,111 @@ func (suite *MethodWrappersTestSuite) TestDelete_DeletedEntry_NoCachedListResult
 	}
 }
 
+func (suite *MethodWrappersTestSuite) TestCreate_InvalidName_ReturnsInvalidInputErr() {
+	p := methodWrappersTestsMockCreatable{newMethodWrappersTestsMockEntry("foo")}
+
+	for _, name := range []string{"", "bar/baz"} {
+		_, err := Create(context.Background(), p, name, []byte{})
+		suite.True(IsInvalidInputErr(err), "expected an InvalidInputErr for name %q", name)
+	}
+	p.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *MethodWrappersTestSuite) TestCreate_ReturnsCreateError() {
+	p := methodWrappersTestsMockCreatable{newMethodWrappersTestsMockEntry("foo")}
+
+	expectedErr := fmt.Errorf("an error")
+	p.On("Create", mock.Anything, "bar", []byte("data")).Return(&methodWrappersTestsMockEntry{}, expectedErr)
+
+	_, err := Create(context.Background(), p, "bar", []byte("data"))
+	suite.Equal(expectedErr, err)
+}
+
+func (suite *MethodWrappersTestSuite) TestCreate_SetsChildIDAndClearsParentList() {
+	p := methodWrappersTestsMockCreatable{newMethodWrappersTestsMockEntry("foo")}
+	p.SetTestID("/foo")
+	child := newMethodWrappersTestsMockEntry("bar")
+	p.On("Create", mock.Anything, "bar", []byte("data")).Return(child, nil)
+
+	suite.cache.On("Delete", opKeyRegex("List", "/foo")).Return([]string{})
+	suite.cache.On("Delete", opKeyRegex("Missing", "/foo/bar")).Return([]string{})
+
+	entry, err := Create(context.Background(), p, "bar", []byte("data"))
+	if suite.NoError(err) {
+		suite.Equal("/foo/bar", ID(entry))
+		p.AssertExpectations(suite.T())
+		suite.cache.AssertExpectations(suite.T())
+	}
+}
+
+func (suite *MethodWrappersTestSuite) TestCreateDir_InvalidName_ReturnsInvalidInputErr() {
+	p := methodWrappersTestsMockDirCreatable{newMethodWrappersTestsMockEntry("foo")}
+
+	for _, name := range []string{"", "bar/baz"} {
+		_, err := CreateDir(context.Background(), p, name)
+		suite.True(IsInvalidInputErr(err), "expected an InvalidInputErr for name %q", name)
+	}
+	p.AssertNotCalled(suite.T(), "CreateDir", mock.Anything, mock.Anything)
+}
+
+func (suite *MethodWrappersTestSuite) TestCreateDir_SetsChildIDAndClearsParentList() {
+	p := methodWrappersTestsMockDirCreatable{newMethodWrappersTestsMockEntry("foo")}
+	p.SetTestID("/foo")
+	child := methodWrappersTestsMockDirCreatable{newMethodWrappersTestsMockEntry("bar")}
+	p.On("CreateDir", mock.Anything, "bar").Return(child, nil)
+
+	suite.cache.On("Delete", opKeyRegex("List", "/foo")).Return([]string{})
+	suite.cache.On("Delete", opKeyRegex("Missing", "/foo/bar")).Return([]string{})
+
+	dir, err := CreateDir(context.Background(), p, "bar")
+	if suite.NoError(err) {
+		suite.Equal("/foo/bar", ID(dir))
+		p.AssertExpectations(suite.T())
+		suite.cache.AssertExpectations(suite.T())
+	}
+}
+
+func (suite *MethodWrappersTestSuite) TestRename_InvalidName_ReturnsInvalidInputErr() {
+	e := methodWrappersTestsMockRenamable{newMethodWrappersTestsMockEntry("bar")}
+	p := methodWrappersTestsMockCreatable{newMethodWrappersTestsMockEntry("foo")}
+
+	for _, name := range []string{"", "bar/baz"} {
+		_, err := Rename(context.Background(), e, p, name)
+		suite.True(IsInvalidInputErr(err), "expected an InvalidInputErr for name %q", name)
+	}
+	e.AssertNotCalled(suite.T(), "Rename", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *MethodWrappersTestSuite) TestRename_ReturnsRenameError() {
+	e := methodWrappersTestsMockRenamable{newMethodWrappersTestsMockEntry("bar")}
+	p := methodWrappersTestsMockCreatable{newMethodWrappersTestsMockEntry("foo")}
+
+	e.On("Rename", mock.Anything, p, "baz").Return(&methodWrappersTestsMockEntry{}, ErrCrossParentRename)
+
+	_, err := Rename(context.Background(), e, p, "baz")
+	suite.Equal(ErrCrossParentRename, err)
+}
+
+func (suite *MethodWrappersTestSuite) TestRename_SetsIDAndClearsBothParentLists() {
+	e := methodWrappersTestsMockRenamable{newMethodWrappersTestsMockEntry("bar")}
+	e.SetTestID("/foo/bar")
+	p := methodWrappersTestsMockCreatable{newMethodWrappersTestsMockEntry("qux")}
+	p.SetTestID("/qux")
+	moved := newMethodWrappersTestsMockEntry("baz")
+	e.On("Rename", mock.Anything, p, "baz").Return(moved, nil)
+
+	suite.cache.On("Get", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.cache.On("Delete", mock.Anything).Return([]string{})
+
+	entry, err := Rename(context.Background(), e, p, "baz")
+	if suite.NoError(err) {
+		suite.Equal("/qux/baz", ID(entry))
+		suite.cache.AssertCalled(suite.T(), "Delete", allOpKeysIncludingChildrenRegex("/foo/bar"))
+		suite.cache.AssertCalled(suite.T(), "Delete", opKeyRegex("List", "/foo"))
+		suite.cache.AssertCalled(suite.T(), "Delete", opKeyRegex("List", "/qux"))
+	}
+}
+
 func TestMethodWrappers(t *testing.T) {
 	suite.Run(t, new(MethodWrappersTestSuite))
 }
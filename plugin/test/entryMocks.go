@@ -2,6 +2,7 @@ package plugintest
 
 import (
 	"context"
+	"io"
 
 	"github.com/puppetlabs/wash/plugin"
 	"github.com/stretchr/testify/mock"
@@ -114,3 +115,85 @@ func (m *MockBlockReadWrite) Write(ctx context.Context, p []byte) error {
 
 var _ = plugin.BlockReadable(&MockBlockReadWrite{})
 var _ = plugin.Writable(&MockBlockReadWrite{})
+
+// MockSequentialRead only mocks ReadStream operations.
+type MockSequentialRead struct {
+	MockBase
+}
+
+// NewMockSequentialRead creates a new "mock" entry for sequential reads.
+func NewMockSequentialRead() *MockSequentialRead {
+	m := &MockSequentialRead{MockBase{EntryBase: plugin.NewEntry("mocksr")}}
+	m.SetTestID("/mocksr")
+	return m
+}
+
+func (m *MockSequentialRead) ReadStream(ctx context.Context) (io.ReadCloser, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+var _ = plugin.SequentialReadable(&MockSequentialRead{})
+
+// MockParent only mocks List operations.
+type MockParent struct {
+	MockBase
+}
+
+// NewMockParent creates a new "mock" entry with List.
+func NewMockParent() *MockParent {
+	return &MockParent{MockBase: *NewMockBase()}
+}
+
+// ChildSchemas returns nil because the mock's children are unknown.
+func (m *MockParent) ChildSchemas() []*plugin.EntrySchema {
+	return nil
+}
+
+// List calls the mocked List method.
+func (m *MockParent) List(ctx context.Context) ([]plugin.Entry, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]plugin.Entry), args.Error(1)
+}
+
+var _ = plugin.Parent(&MockParent{})
+
+// MockDelete only mocks Delete operations.
+type MockDelete struct {
+	MockBase
+}
+
+// NewMockDelete creates a new "mock" entry with Delete.
+func NewMockDelete(name string) *MockDelete {
+	m := &MockDelete{MockBase: MockBase{EntryBase: plugin.NewEntry(name)}}
+	m.SetTestID("/mock/" + name)
+	return m
+}
+
+// Delete calls the mocked Delete method.
+func (m *MockDelete) Delete(ctx context.Context) (bool, error) {
+	args := m.Called(ctx)
+	return args.Bool(0), args.Error(1)
+}
+
+var _ = plugin.Deletable(&MockDelete{})
+
+// MockRename only mocks Rename operations.
+type MockRename struct {
+	MockBase
+}
+
+// NewMockRename creates a new "mock" entry with Rename.
+func NewMockRename(name string) *MockRename {
+	m := &MockRename{MockBase: MockBase{EntryBase: plugin.NewEntry(name)}}
+	m.SetTestID("/mock/" + name)
+	return m
+}
+
+// Rename calls the mocked Rename method.
+func (m *MockRename) Rename(ctx context.Context, newParent plugin.Parent, newName string) (plugin.Entry, error) {
+	args := m.Called(ctx, newParent, newName)
+	return args.Get(0).(plugin.Entry), args.Error(1)
+}
+
+var _ = plugin.Renamable(&MockRename{})
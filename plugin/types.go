@@ -201,6 +201,20 @@ type Readable interface {
 	Read(context.Context) ([]byte, error)
 }
 
+// SequentialReadable is an entry with data that's read from start to end, like
+// a log. Implement it instead of Readable when the data can be too large to hold
+// in memory. Its data isn't cached, so reads that don't continue from where the
+// last one ended start a new stream. If an entry implements both, Wash uses
+// ReadStream.
+//
+// The context passed to ReadStream lasts until Wash closes the stream. A
+// SequentialReadable entry should set its Size attribute if it knows it.
+// Otherwise its size is reported as 0.
+type SequentialReadable interface {
+	Entry
+	ReadStream(context.Context) (io.ReadCloser, error)
+}
+
 // Writable is an entry that we can write new data to. What that means can be
 // implementation-specific; it could be overwriting a file, submitting a
 // configuration change to an API, or writing data to a queue. It doesn't
@@ -214,6 +228,49 @@ type Writable interface {
 	Write(context.Context, []byte) error
 }
 
+// StreamWritable is a Writable entry that can take its new data as it's copied,
+// rather than all at once, like an upload that's sent in parts. WriteStream
+// returns a writer for size bytes of new data; closing it finishes the write.
+// If Wash can't copy all of the data, it cancels ctx before closing the writer,
+// so the write should be abandoned when ctx is cancelled.
+type StreamWritable interface {
+	Writable
+	WriteStream(ctx context.Context, size int64) (io.WriteCloser, error)
+}
+
+// Creatable is a parent that can create new children. Create should create a
+// child with the given name and initial content, and return the new child.
+type Creatable interface {
+	Parent
+	Create(ctx context.Context, name string, content []byte) (Entry, error)
+}
+
+// DirCreatable is a parent that can create new child directories. CreateDir
+// should create an empty child with the given name, and return the new child.
+type DirCreatable interface {
+	Parent
+	CreateDir(ctx context.Context, name string) (Parent, error)
+}
+
+// Renamable is an entry that can be renamed or moved. Rename should move the
+// entry to newName under newParent, and return the moved entry. newParent is
+// the entry's current parent if it's only being renamed. If the entry can't be
+// moved to newParent (e.g. because it's from a different plugin), then Rename
+// should return ErrCrossParentRename.
+type Renamable interface {
+	Entry
+	Rename(ctx context.Context, newParent Parent, newName string) (Entry, error)
+}
+
+// Linkable is an entry that links to another entry, similar to a symbolic link.
+// LinkTarget returns the linked entry's path. Paths that start with a '/' are
+// rooted at Wash's mountpoint, like an entry's ID. Other paths are relative to
+// the link's parent. Plugins should use Link instead of implementing this.
+type Linkable interface {
+	Entry
+	LinkTarget() string
+}
+
 // Deletable is an entry that can be deleted. Entries that implement Delete
 // should ensure that it and all its children are removed. If the entry has
 // any dependencies that need to be deleted, then Delete should return an
@@ -238,6 +295,15 @@ type Signalable interface {
 	Signal(context.Context, string) error
 }
 
+// Forwardable is an entry that can forward local ports to ports on the entry. Each
+// port mapping has the form "<local_port>:<remote_port>", or "<port>" if both ports
+// are the same. Forward should listen on the local ports and forward connections
+// until the passed-in context is cancelled, writing any status messages to out.
+type Forwardable interface {
+	Entry
+	Forward(ctx context.Context, ports []string, out io.Writer) error
+}
+
 // This interface exists to break the circular dependency between plugin and external.
 // The external plugin implementation is in its own module so it can use other modules
 // that implement new features and have dependencies on this module.
@@ -2,12 +2,15 @@ package transport
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/avast/retry-go"
@@ -26,6 +29,45 @@ import (
 var connectionCache = datastore.NewMemCache().WithEvicted(closeConnection)
 var expires = 15 * time.Second
 
+// Cache pushed ephemeral keys so we only push a key once per target while it's still authorized.
+// Services like EC2 Instance Connect authorize a pushed key for 60 seconds, so expire it earlier
+// to leave time to connect.
+var pushedKeyCache = datastore.NewMemCache()
+var pushedKeyExpires = 45 * time.Second
+
+// The ephemeral key pair is generated on first use and never leaves this process.
+var ephemeralKey struct {
+	once   sync.Once
+	signer ssh.Signer
+	err    error
+}
+
+func getEphemeralSigner() (ssh.Signer, error) {
+	ephemeralKey.once.Do(func() {
+		var key *rsa.PrivateKey
+		if key, ephemeralKey.err = rsa.GenerateKey(rand.Reader, 2048); ephemeralKey.err != nil {
+			return
+		}
+		ephemeralKey.signer, ephemeralKey.err = ssh.NewSignerFromKey(key)
+	})
+	return ephemeralKey.signer, ephemeralKey.err
+}
+
+// pushEphemeralKey authorizes the ephemeral public key for conf.user via conf.pushKey, and returns
+// the signer to authenticate with.
+func pushEphemeralKey(ctx context.Context, conf sshConfig, connID string) (ssh.Signer, error) {
+	signer, err := getEphemeralSigner()
+	if err != nil {
+		return nil, err
+	}
+	_, err = pushedKeyCache.GetOrUpdate("", connID, pushedKeyExpires, false, func() (interface{}, error) {
+		publicKey := string(ssh.MarshalAuthorizedKey(signer.PublicKey()))
+		activity.Record(ctx, "Pushing ephemeral public key for %v", connID)
+		return true, conf.pushKey(ctx, conf.user, publicKey)
+	})
+	return signer, err
+}
+
 func closeConnection(id string, obj interface{}) {
 	if client, ok := obj.(*ssh.Client); ok {
 		client.Close()
@@ -45,9 +87,12 @@ type sshConfig struct {
 	host, port, user, password string
 	identityFiles              []string
 	hostKeyCallback            ssh.HostKeyCallback
+	pushKey                    KeyPusher
 }
 
 func getConnInfo(ctx context.Context, id Identity) (conf sshConfig, err error) {
+	conf.pushKey = id.PushKey
+
 	if conf.host, err = ssh_config.GetStrict(id.Host, "HostName"); err != nil {
 		return
 	}
@@ -154,6 +199,14 @@ func sshConnect(ctx context.Context, conf sshConfig, retries uint) (*ssh.Client,
 		}
 
 		var authmethod []ssh.AuthMethod
+		// Try the ephemeral key first because it was pushed specifically for this connection.
+		if conf.pushKey != nil {
+			if signer, err := pushEphemeralKey(ctx, conf, connID); err != nil {
+				activity.Record(ctx, "Unable to push ephemeral key for %v: %v", connID, err)
+			} else {
+				authmethod = append(authmethod, ssh.PublicKeys(signer))
+			}
+		}
 		if conf.password != "" {
 			authmethod = append(authmethod, ssh.Password(conf.password))
 		}
@@ -212,13 +265,21 @@ type Identity struct {
 	// Retries can be set to a non-zero value to retry every 500ms for that many times.
 	Retries uint `json:"retries"`
 	Port    uint `json:"port"`
+	// PushKey can be set to authorize an ephemeral key on the target before connecting, such as
+	// with EC2 Instance Connect. It's tried before other authentication methods.
+	PushKey KeyPusher `json:"-"`
 }
 
+// KeyPusher authorizes an SSH public key, in authorized_keys format, for user on a target.
+type KeyPusher func(ctx context.Context, user string, publicKey string) error
+
 // ExecSSH executes against a target via SSH. It will look up port, user, and other configuration
 // by exact hostname match from default SSH config files. Identity can be used to override the
 // user configured in SSH config. If opts.Elevate is true, will attempt to `sudo` as root.
 //
-// If present, a local SSH agent will be used for authentication.
+// If present, a local SSH agent will be used for authentication. If id.PushKey is set, a key pair
+// is generated for the lifetime of the process and its public key is pushed to the target before
+// connecting. Pushes are cached for 45 seconds per user and target.
 //
 // Lots of SSH configuration is currently omitted, such as global known hosts files, finding known
 // hosts from the config, identity file from config... pretty much everything but port and user
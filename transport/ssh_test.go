@@ -141,6 +141,7 @@ func (suite *SSHTestSuite) SetupTest() {
 
 func (suite *SSHTestSuite) TearDownTest() {
 	connectionCache.Flush()
+	pushedKeyCache.Flush()
 }
 
 func (suite *SSHTestSuite) TearDownSuite() {
@@ -227,6 +228,39 @@ func (suite *SSHTestSuite) TestExec_WithPassword() {
 	}
 }
 
+func (suite *SSHTestSuite) TestExec_WithPushKey() {
+	var pushedUser, pushedKey string
+	suite.m.On("Handler", mock.Anything).Run(func(args mock.Arguments) {})
+	// Only accept the pushed key.
+	suite.m.On("PublicKeyHandler", mock.Anything, mock.MatchedBy(func(key gssh.PublicKey) bool {
+		return pushedKey != "" && string(ssh.MarshalAuthorizedKey(key)) == pushedKey
+	})).Return(true)
+	suite.m.On("PublicKeyHandler", mock.Anything, mock.Anything).Return(false)
+
+	identity := suite.Identity()
+	identity.User = "ec2-user"
+	pushes := 0
+	identity.PushKey = func(ctx context.Context, user string, publicKey string) error {
+		pushes++
+		pushedUser, pushedKey = user, publicKey
+		return nil
+	}
+
+	for i := 0; i < 2; i++ {
+		cmd, err := ExecSSH(context.Background(), identity, []string{"echo", "hello"}, plugin.ExecOptions{})
+		if suite.NoError(err) {
+			<-cmd.OutputCh()
+			exit, err := cmd.ExitCode()
+			suite.NoError(err)
+			suite.Zero(exit)
+		}
+		connectionCache.Flush()
+	}
+	suite.Equal("ec2-user", pushedUser)
+	// The push is cached even though the connection isn't.
+	suite.Equal(1, pushes)
+}
+
 func (suite *SSHTestSuite) TestExec_IgnoreHostKey() {
 	// Tests that a mechanism exists to ignore host key checking
 	suite.m.On("Handler", mock.Anything).Run(func(args mock.Arguments) {})
@@ -0,0 +1,161 @@
+package transport
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unicode/utf16"
+
+	"github.com/masterzen/winrm"
+	"github.com/puppetlabs/wash/activity"
+	"github.com/puppetlabs/wash/plugin"
+)
+
+// WinRMIdentity identifies a Windows host and the credentials to connect to it with.
+type WinRMIdentity struct {
+	Host string
+	// Port defaults to 5986 when HTTPS is true and 5985 otherwise.
+	Port  int
+	HTTPS bool
+	// Insecure skips verifying the host's certificate when using HTTPS.
+	Insecure bool
+	// Basic uses basic authentication instead of NTLM. WinRM only allows basic authentication
+	// over HTTP if the host's configured with AllowUnencrypted.
+	Basic    bool
+	User     string
+	Password string
+}
+
+func (id WinRMIdentity) endpoint() *winrm.Endpoint {
+	port := id.Port
+	if port == 0 {
+		if id.HTTPS {
+			port = 5986
+		} else {
+			port = 5985
+		}
+	}
+	return winrm.NewEndpoint(id.Host, port, id.HTTPS, id.Insecure, nil, nil, nil, 0)
+}
+
+// ExecWinRM executes a command on a Windows host over WinRM. The command runs as a PowerShell
+// script. A command without arguments is run as-is so that it can be a PowerShell expression;
+// otherwise the command is invoked with the call operator and quoted arguments.
+//
+// WinRM doesn't support allocating a TTY or elevating, so opts.Tty and opts.Elevate are ignored.
+// Connect as an administrator to run privileged commands.
+func ExecWinRM(ctx context.Context, id WinRMIdentity, cmd []string, opts plugin.ExecOptions) (plugin.ExecCommand, error) {
+	params := *winrm.DefaultParameters
+	if !id.Basic {
+		params.TransportDecorator = func() winrm.Transporter { return &winrm.ClientNTLM{} }
+	}
+	client, err := winrm.NewClientWithParameters(id.endpoint(), id.User, id.Password, &params)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create WinRM client: %v", err)
+	}
+
+	shell, err := client.CreateShell()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to connect: %v", err)
+	}
+
+	script := powershellScript(cmd)
+	activity.Record(ctx, "Executing on %v over WinRM: %v", id.Host, script)
+	command, err := shell.Execute(encodePowershell(script))
+	if err != nil {
+		activity.Record(ctx, "Closing WinRM shell for %v: %v", id.Host, shell.Close())
+		return nil, err
+	}
+
+	execCmd := plugin.NewExecCommand(ctx)
+	done := make(chan struct{})
+	execCmd.SetStopFunc(func() {
+		select {
+		case <-done:
+			// The command finished and its shell's already closed.
+		default:
+			activity.Record(ctx, "Terminating WinRM command on context termination for %v: %v", id.Host, command.Close())
+		}
+	})
+
+	go func() {
+		if opts.Stdin != nil {
+			if _, err := io.Copy(command.Stdin, opts.Stdin); err != nil {
+				activity.Record(ctx, "Error sending stdin to %v: %v", id.Host, err)
+			}
+		}
+		if err := command.Stdin.Close(); err != nil {
+			activity.Record(ctx, "Error closing stdin on %v: %v", id.Host, err)
+		}
+	}()
+
+	go func() {
+		defer close(done)
+
+		var wg sync.WaitGroup
+		var stdoutErr, stderrErr error
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, stdoutErr = io.Copy(execCmd.Stdout(), command.Stdout)
+		}()
+		go func() {
+			defer wg.Done()
+			_, stderrErr = io.Copy(execCmd.Stderr(), command.Stderr)
+		}()
+		command.Wait()
+		wg.Wait()
+
+		// Closing the command after it finishes releases it on the host.
+		if err := command.Close(); err != nil {
+			activity.Record(ctx, "Error closing WinRM command for %v: %v", id.Host, err)
+		}
+		activity.Record(ctx, "Closing WinRM shell for %v: %v", id.Host, shell.Close())
+
+		err := stdoutErr
+		if err == nil {
+			err = stderrErr
+		}
+		execCmd.CloseStreamsWithError(err)
+		if err != nil {
+			execCmd.SetExitCodeErr(err)
+		} else {
+			execCmd.SetExitCode(command.ExitCode())
+		}
+	}()
+	return execCmd, nil
+}
+
+// powershellScript converts the command to a PowerShell script. PowerShell only reports a native
+// command's exit code if the script exits with it, so the script does that when it fails.
+func powershellScript(cmd []string) string {
+	var script string
+	if len(cmd) == 1 {
+		script = cmd[0]
+	} else {
+		quoted := make([]string, len(cmd))
+		for i, arg := range cmd {
+			quoted[i] = "'" + strings.ReplaceAll(arg, "'", "''") + "'"
+		}
+		script = "& " + strings.Join(quoted, " ")
+	}
+	// Suppress progress output, which powershell.exe serializes to stderr as CLIXML.
+	return "$ProgressPreference = 'SilentlyContinue'\n" +
+		script + "\n" +
+		"if (-not $?) { if ($LASTEXITCODE) { exit $LASTEXITCODE }; exit 1 }"
+}
+
+// encodePowershell returns a powershell.exe command line that runs the script. The script's
+// encoded as base64 UTF-16LE so it doesn't need to be escaped for cmd.exe.
+func encodePowershell(script string) string {
+	codes := utf16.Encode([]rune(script))
+	encoded := make([]byte, 2*len(codes))
+	for i, code := range codes {
+		binary.LittleEndian.PutUint16(encoded[2*i:], code)
+	}
+	return "powershell.exe -NonInteractive -NoProfile -EncodedCommand " + base64.StdEncoding.EncodeToString(encoded)
+}
@@ -0,0 +1,198 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const winrmEnvelope = `<s:Envelope xml:lang="en-US" xmlns:s="http://www.w3.org/2003/05/soap-envelope" xmlns:a="http://schemas.xmlsoap.org/ws/2004/08/addressing" xmlns:x="http://schemas.xmlsoap.org/ws/2004/09/transfer" xmlns:w="http://schemas.dmtf.org/wbem/wsman/1/wsman.xsd" xmlns:rsp="http://schemas.microsoft.com/wbem/wsman/1/windows/shell"><s:Header></s:Header><s:Body>%v</s:Body></s:Envelope>`
+
+const commandID = "1A6DEE6B-EC68-4DD6-87E9-030C0048ECC4"
+
+// mockWinRM is a minimal WinRM endpoint that runs a single command. It records the command line
+// and stdin it receives, and responds to the first Receive with the configured output.
+type mockWinRM struct {
+	mux      sync.Mutex
+	user     string
+	password string
+	stdout   string
+	stderr   string
+	exitCode int
+
+	commandLine string
+	stdin       bytes.Buffer
+	actions     []string
+}
+
+var actionRegex = regexp.MustCompile(`<a:Action[^>]*>([^<]*)</a:Action>`)
+var commandRegex = regexp.MustCompile(`<rsp:Command><!\[CDATA\[(.*?)\]\]></rsp:Command>`)
+var streamRegex = regexp.MustCompile(`<rsp:Stream[^>]*>([^<]*)</rsp:Stream>`)
+
+func (m *mockWinRM) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if user, password, ok := r.BasicAuth(); !ok || user != m.user || password != m.password {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	match := actionRegex.FindSubmatch(body)
+	if match == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	action := string(match[1])
+	m.actions = append(m.actions, action[strings.LastIndex(action, "/")+1:])
+
+	w.Header().Set("Content-Type", "application/soap+xml")
+	switch {
+	case strings.HasSuffix(action, "transfer/Create"):
+		fmt.Fprintf(w, winrmEnvelope, `<x:ResourceCreated><a:ReferenceParameters><w:SelectorSet><w:Selector Name="ShellId">SHELL-ID</w:Selector></w:SelectorSet></a:ReferenceParameters></x:ResourceCreated>`)
+	case strings.HasSuffix(action, "shell/Command"):
+		if match := commandRegex.FindSubmatch(body); match != nil {
+			m.commandLine = string(match[1])
+		}
+		fmt.Fprintf(w, winrmEnvelope, `<rsp:CommandResponse><rsp:CommandId>`+commandID+`</rsp:CommandId></rsp:CommandResponse>`)
+	case strings.HasSuffix(action, "shell/Send"):
+		for _, match := range streamRegex.FindAllSubmatch(body, -1) {
+			data, _ := base64.StdEncoding.DecodeString(string(match[1]))
+			m.stdin.Write(data)
+		}
+		fmt.Fprintf(w, winrmEnvelope, `<rsp:SendResponse></rsp:SendResponse>`)
+	case strings.HasSuffix(action, "shell/Receive"):
+		fmt.Fprintf(w, winrmEnvelope, `<rsp:ReceiveResponse>`+
+			`<rsp:Stream Name="stdout" CommandId="`+commandID+`">`+base64.StdEncoding.EncodeToString([]byte(m.stdout))+`</rsp:Stream>`+
+			`<rsp:Stream Name="stderr" CommandId="`+commandID+`">`+base64.StdEncoding.EncodeToString([]byte(m.stderr))+`</rsp:Stream>`+
+			`<rsp:CommandState CommandId="`+commandID+`" State="http://schemas.microsoft.com/wbem/wsman/1/windows/shell/CommandState/Done">`+
+			`<rsp:ExitCode>`+strconv.Itoa(m.exitCode)+`</rsp:ExitCode></rsp:CommandState></rsp:ReceiveResponse>`)
+	default:
+		// Signal and Delete don't need a meaningful response.
+		fmt.Fprintf(w, winrmEnvelope, "")
+	}
+}
+
+// decodedScript returns the PowerShell script encoded in the received command line.
+func (m *mockWinRM) decodedScript(t *testing.T) string {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	fields := strings.Fields(m.commandLine)
+	require.NotEmpty(t, fields)
+	assert.Equal(t, "powershell.exe", fields[0])
+	encoded, err := base64.StdEncoding.DecodeString(fields[len(fields)-1])
+	require.NoError(t, err)
+	codes := make([]uint16, len(encoded)/2)
+	for i := range codes {
+		codes[i] = uint16(encoded[2*i]) | uint16(encoded[2*i+1])<<8
+	}
+	return string(utf16.Decode(codes))
+}
+
+func startMockWinRM(t *testing.T, m *mockWinRM) (WinRMIdentity, func()) {
+	server := httptest.NewServer(m)
+	host, portStr, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+	id := WinRMIdentity{Host: host, Port: port, Basic: true, User: m.user, Password: m.password}
+	return id, server.Close
+}
+
+func collectOutput(t *testing.T, cmd plugin.ExecCommand) (string, string, int) {
+	var stdout, stderr bytes.Buffer
+	for chunk := range cmd.OutputCh() {
+		require.NoError(t, chunk.Err)
+		if chunk.StreamID == plugin.Stdout {
+			stdout.WriteString(chunk.Data)
+		} else {
+			stderr.WriteString(chunk.Data)
+		}
+	}
+	exitCode, err := cmd.ExitCode()
+	require.NoError(t, err)
+	return stdout.String(), stderr.String(), exitCode
+}
+
+func TestExecWinRM(t *testing.T) {
+	m := &mockWinRM{user: "Administrator", password: "pass", stdout: "hello\r\n", stderr: "warning\r\n", exitCode: 3}
+	id, stop := startMockWinRM(t, m)
+	defer stop()
+
+	cmd, err := ExecWinRM(context.Background(), id, []string{"echo", "it's"}, plugin.ExecOptions{})
+	require.NoError(t, err)
+	stdout, stderr, exitCode := collectOutput(t, cmd)
+
+	assert.Equal(t, "hello\r\n", stdout)
+	assert.Equal(t, "warning\r\n", stderr)
+	assert.Equal(t, 3, exitCode)
+	assert.Contains(t, m.decodedScript(t), "& 'echo' 'it''s'\n")
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	assert.Equal(t, "Create", m.actions[0])
+	assert.Equal(t, "Delete", m.actions[len(m.actions)-1])
+}
+
+func TestExecWinRM_Expression(t *testing.T) {
+	m := &mockWinRM{user: "Administrator", password: "pass"}
+	id, stop := startMockWinRM(t, m)
+	defer stop()
+
+	cmd, err := ExecWinRM(context.Background(), id, []string{"Get-ChildItem C:\\ | Select-Object Name"}, plugin.ExecOptions{})
+	require.NoError(t, err)
+	_, _, exitCode := collectOutput(t, cmd)
+
+	assert.Equal(t, 0, exitCode)
+	assert.Contains(t, m.decodedScript(t), "\nGet-ChildItem C:\\ | Select-Object Name\n")
+}
+
+func TestExecWinRM_Stdin(t *testing.T) {
+	m := &mockWinRM{user: "Administrator", password: "pass"}
+	id, stop := startMockWinRM(t, m)
+	defer stop()
+
+	opts := plugin.ExecOptions{Stdin: strings.NewReader("some input")}
+	cmd, err := ExecWinRM(context.Background(), id, []string{"$input"}, opts)
+	require.NoError(t, err)
+	collectOutput(t, cmd)
+
+	// Stdin is sent concurrently with receiving output, so wait for it to be closed.
+	assert.Eventually(t, func() bool {
+		m.mux.Lock()
+		defer m.mux.Unlock()
+		return m.stdin.String() == "some input"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestExecWinRM_Unauthorized(t *testing.T) {
+	m := &mockWinRM{user: "Administrator", password: "pass"}
+	id, stop := startMockWinRM(t, m)
+	defer stop()
+
+	id.Password = "wrong"
+	_, err := ExecWinRM(context.Background(), id, []string{"hostname"}, plugin.ExecOptions{})
+	assert.Error(t, err)
+}
@@ -8,6 +8,7 @@ package volume
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"strings"
@@ -37,6 +38,29 @@ type Interface interface {
 	VolumeWrite(ctx context.Context, path string, b []byte, m os.FileMode) error
 	// Deletes the volume node at the specified path. Mirrors plugin.Deletable#Delete
 	VolumeDelete(ctx context.Context, path string) (bool, error)
+	// Creates an empty directory at the specified path. Files are created with VolumeWrite.
+	VolumeMkdir(ctx context.Context, path string) error
+	// Moves the volume node at oldPath to newPath. Both paths are in the same volume.
+	VolumeRename(ctx context.Context, oldPath string, newPath string) error
+}
+
+// StreamReader is an Interface that can stream a file's content rather than reading all of it
+// into memory. Its files' content is read as it's copied.
+type StreamReader interface {
+	Interface
+	// Returns a stream of the content of the file associated with path. The context lasts
+	// until the stream's closed.
+	VolumeReadStream(ctx context.Context, path string) (io.ReadCloser, error)
+}
+
+// StreamWriter is an Interface that can write a file's content as it's read from r, rather
+// than from a buffer that holds all of it. Its files take their content as it's copied when
+// they're written through the FUSE filesystem.
+type StreamWriter interface {
+	Interface
+	// Writes the content read from r to the file associated with path. Mode is as for
+	// VolumeWrite. The file should keep its old content unless all of r's written.
+	VolumeWriteFrom(ctx context.Context, path string, r io.Reader, m os.FileMode) error
 }
 
 // Children represents a directory's children. It is a map of <child_basename> => <child_attributes>.
@@ -73,6 +97,18 @@ func List(ctx context.Context, impl Interface) ([]plugin.Entry, error) {
 	return newDir("dummy", plugin.EntryAttributes{}, impl, RootPath).List(ctx)
 }
 
+// Create creates a file in the root of the volume. The entry implementing Interface should
+// use it to implement plugin.Creatable.
+func Create(ctx context.Context, impl Interface, name string, content []byte) (plugin.Entry, error) {
+	return createFile(ctx, impl.(plugin.Parent), impl, nil, RootPath, name, content)
+}
+
+// CreateDir creates a directory in the root of the volume. The entry implementing Interface
+// should use it to implement plugin.DirCreatable.
+func CreateDir(ctx context.Context, impl Interface, name string) (plugin.Parent, error) {
+	return createDir(ctx, impl.(plugin.Parent), impl, nil, RootPath, name)
+}
+
 // ListTTL represents the List op's TTL. The entry implementing volume.Interface should
 // set the List op's TTL to this value.
 const ListTTL = 30 * time.Second
@@ -88,16 +124,198 @@ func deleteNode(ctx context.Context, impl Interface, path string, dirmap *dirMap
 		return
 	}
 
+	if dirmap == nil {
+		// The node's parent wasn't prefetched, so it'll be relisted.
+		return
+	}
+
 	// The node was deleted so remove it from the dirmap and from its parent's children
 	dirmap.mux.Lock()
 	defer dirmap.mux.Unlock()
 
-	delete(dirmap.mp, path)
+	removeNode(dirmap.mp, path, false)
+	return
+}
+
+// createFile creates the file parentPath/name. Similar to deleteNode, it adds the file to the
+// dirmap (if the parent was prefetched) so that it's included when the parent's relisted.
+func createFile(
+	ctx context.Context,
+	parent plugin.Parent,
+	impl Interface,
+	dirmap *dirMap,
+	parentPath string,
+	name string,
+	content []byte,
+) (plugin.Entry, error) {
+	path := parentPath + "/" + name
+	if err := ensureNotExist(ctx, parent, path, name); err != nil {
+		return nil, err
+	}
+
+	mode := os.FileMode(0640)
+	if err := impl.VolumeWrite(ctx, path, content, mode); err != nil {
+		return nil, err
+	}
+
+	attr := newNodeAttributes(mode)
+	attr.SetSize(uint64(len(content)))
+	addNode(dirmap, parentPath, name, attr, false)
+	f := newFile(name, attr, impl, path)
+	f.dirmap = dirmap
+	return f, nil
+}
+
+// createDir creates the directory parentPath/name. Like createFile, it updates the dirmap.
+func createDir(
+	ctx context.Context,
+	parent plugin.Parent,
+	impl Interface,
+	dirmap *dirMap,
+	parentPath string,
+	name string,
+) (plugin.Parent, error) {
+	path := parentPath + "/" + name
+	if err := ensureNotExist(ctx, parent, path, name); err != nil {
+		return nil, err
+	}
+
+	if err := impl.VolumeMkdir(ctx, path); err != nil {
+		return nil, err
+	}
+
+	attr := newNodeAttributes(os.ModeDir | 0750)
+	addNode(dirmap, parentPath, name, attr, true)
+	d := newDir(name, attr, impl, path)
+	d.SetTTLOf(plugin.ListOp, ListTTL)
+	if dirmap != nil {
+		d.dirmap = dirmap
+		d.Prefetched()
+		d.DisableCachingFor(plugin.ListOp)
+	}
+	return d, nil
+}
+
+// renameNode moves the node at path to newParent/newName. newParent must be a directory in the
+// same volume, or the volume itself. Like deleteNode, it removes the node from its dirmap; the
+// node's added to the new parent's dirmap (if it was prefetched) similar to createFile.
+func renameNode(
+	ctx context.Context,
+	impl Interface,
+	path string,
+	dirmap *dirMap,
+	attr plugin.EntryAttributes,
+	newParent plugin.Parent,
+	newName string,
+) (plugin.Entry, error) {
+	var newParentPath string
+	var newDirmap *dirMap
+	switch p := newParent.(type) {
+	case *dir:
+		if plugin.ID(p.impl) != plugin.ID(impl) {
+			return nil, plugin.ErrCrossParentRename
+		}
+		newParentPath, newDirmap = p.path, p.dirmap
+	case Interface:
+		if plugin.ID(p) != plugin.ID(impl) {
+			return nil, plugin.ErrCrossParentRename
+		}
+		newParentPath = RootPath
+	default:
+		return nil, plugin.ErrCrossParentRename
+	}
+
+	newPath := newParentPath + "/" + newName
+	if newPath == path {
+		return nil, fmt.Errorf("%v is already at %v", path, newPath)
+	}
+	isDir := attr.HasMode() && attr.Mode().IsDir()
+	if isDir && strings.HasPrefix(newPath, path+"/") {
+		return nil, fmt.Errorf("cannot move %v into itself", path)
+	}
+	if err := impl.VolumeRename(ctx, path, newPath); err != nil {
+		return nil, err
+	}
+
+	if dirmap != nil {
+		dirmap.mux.Lock()
+		removeNode(dirmap.mp, path, isDir)
+		dirmap.mux.Unlock()
+	}
+	if isDir {
+		// The moved directory's subtree is relisted from its new location.
+		if newDirmap != nil {
+			newDirmap.mux.Lock()
+			removeNode(newDirmap.mp, newPath, true)
+			if parentChildren, ok := newDirmap.mp[newParentPath]; ok && parentChildren != nil {
+				parentChildren[newName] = attr
+			}
+			newDirmap.mux.Unlock()
+		}
+		d := newDir(newName, attr, impl, newPath)
+		d.SetTTLOf(plugin.ListOp, ListTTL)
+		return d, nil
+	}
+
+	addNode(newDirmap, newParentPath, newName, attr, false)
+	f := newFile(newName, attr, impl, newPath)
+	f.dirmap = newDirmap
+	return f, nil
+}
+
+// removeNode removes the node at path from the dirmap, including its subtree if it's a
+// directory. The caller must hold the dirmap's lock.
+func removeNode(mp DirMap, path string, isDir bool) {
+	delete(mp, path)
+	if isDir {
+		for p := range mp {
+			if strings.HasPrefix(p, path+"/") {
+				delete(mp, p)
+			}
+		}
+	}
 	segments := strings.Split(path, "/")
 	parentPath := strings.Join(segments[:len(segments)-1], "/")
-	if parentChildren, ok := dirmap.mp[parentPath]; ok {
-		basename := segments[len(segments)-1]
-		delete(parentChildren, basename)
+	if parentChildren, ok := mp[parentPath]; ok {
+		delete(parentChildren, segments[len(segments)-1])
+	}
+}
+
+func ensureNotExist(ctx context.Context, parent plugin.Parent, path string, name string) error {
+	children, err := plugin.List(ctx, parent)
+	if err != nil {
+		return err
+	}
+	if _, ok := children.Load(name); ok {
+		return fmt.Errorf("%v already exists: %w", path, os.ErrExist)
+	}
+	return nil
+}
+
+func newNodeAttributes(mode os.FileMode) plugin.EntryAttributes {
+	now := time.Now()
+	attr := plugin.EntryAttributes{}
+	attr.
+		SetMode(mode).
+		SetAtime(now).
+		SetMtime(now).
+		SetCtime(now)
+	return attr
+}
+
+// addNode adds a new node to its parent's children in the dirmap. New directories are
+// recorded as explored because they're empty.
+func addNode(dirmap *dirMap, parentPath string, name string, attr plugin.EntryAttributes, isDir bool) {
+	if dirmap == nil {
+		return
+	}
+
+	dirmap.mux.Lock()
+	defer dirmap.mux.Unlock()
+	if parentChildren, ok := dirmap.mp[parentPath]; ok && parentChildren != nil {
+		parentChildren[name] = attr
+	}
+	if isDir {
+		dirmap.mp[parentPath+"/"+name] = Children{}
 	}
-	return
 }
@@ -3,9 +3,11 @@ package volume
 import (
 	"context"
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/puppetlabs/wash/plugin"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -69,6 +71,87 @@ func (s *coreTestSuite) TestDeleteNode_DeletedNode_UpdatesDirMap() {
 	}
 }
 
+func (s *coreTestSuite) TestRenameNode_MovesFileBetweenPrefetchedDirs() {
+	ctx := context.Background()
+	mockImpl := &mockDirEntry{EntryBase: plugin.NewEntry("foo")}
+	mockImpl.SetTestID("/foo")
+	attr := plugin.EntryAttributes{}
+	attr.SetMode(0644)
+	dirMap := &dirMap{
+		mp: map[string]Children{
+			"/bar": map[string]plugin.EntryAttributes{"baz": attr},
+			"/qux": map[string]plugin.EntryAttributes{},
+		},
+	}
+	newParent := newDir("qux", plugin.EntryAttributes{}, mockImpl, "/qux")
+	newParent.dirmap = dirMap
+
+	mockImpl.On("VolumeRename", ctx, "/bar/baz", "/qux/quux").Return(nil)
+
+	entry, err := renameNode(ctx, mockImpl, "/bar/baz", dirMap, attr, newParent, "quux")
+	if s.NoError(err) {
+		if f, ok := entry.(*file); s.True(ok) {
+			s.Equal("/qux/quux", f.path)
+			s.Equal(dirMap, f.dirmap)
+		}
+		s.NotContains(dirMap.mp["/bar"], "baz")
+		s.Contains(dirMap.mp["/qux"], "quux")
+	}
+}
+
+// mockRootEntry is a volume that's also the root directory, like a Docker volume.
+type mockRootEntry struct {
+	mockDirEntry
+}
+
+func (m *mockRootEntry) ChildSchemas() []*plugin.EntrySchema {
+	return ChildSchemas()
+}
+
+func (m *mockRootEntry) List(ctx context.Context) ([]plugin.Entry, error) {
+	return List(ctx, m)
+}
+
+func (s *coreTestSuite) TestRenameNode_MovedDirIsRelisted() {
+	ctx := context.Background()
+	mockImpl := &mockRootEntry{mockDirEntry{EntryBase: plugin.NewEntry("foo")}}
+	mockImpl.SetTestID("/foo")
+	attr := plugin.EntryAttributes{}
+	attr.SetMode(os.ModeDir | 0755)
+	dirMap := &dirMap{
+		mp: map[string]Children{
+			"/bar":     map[string]plugin.EntryAttributes{"baz": attr},
+			"/bar/baz": map[string]plugin.EntryAttributes{"a": plugin.EntryAttributes{}},
+		},
+	}
+
+	mockImpl.On("VolumeRename", ctx, "/bar/baz", "/quux").Return(nil)
+
+	entry, err := renameNode(ctx, mockImpl, "/bar/baz", dirMap, attr, mockImpl, "quux")
+	if s.NoError(err) {
+		if d, ok := entry.(*dir); s.True(ok) {
+			s.Equal("/quux", d.path)
+			s.Nil(d.dirmap)
+		}
+		s.NotContains(dirMap.mp, "/bar/baz")
+		s.NotContains(dirMap.mp["/bar"], "baz")
+	}
+}
+
+func (s *coreTestSuite) TestRenameNode_RejectsOtherParents() {
+	ctx := context.Background()
+	mockImpl := &mockDirEntry{EntryBase: plugin.NewEntry("foo")}
+	mockImpl.SetTestID("/foo")
+	other := &mockRootEntry{mockDirEntry{EntryBase: plugin.NewEntry("other")}}
+	other.SetTestID("/other")
+
+	_, err := renameNode(ctx, mockImpl, "/bar", nil, plugin.EntryAttributes{}, other, "bar")
+	s.Equal(plugin.ErrCrossParentRename, err)
+	_, err = renameNode(ctx, mockImpl, "/bar", nil, plugin.EntryAttributes{}, newDir("baz", plugin.EntryAttributes{}, other, "/baz"), "bar")
+	s.Equal(plugin.ErrCrossParentRename, err)
+	mockImpl.AssertNotCalled(s.T(), "VolumeRename", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestCore(t *testing.T) {
 	suite.Run(t, new(coreTestSuite))
 }
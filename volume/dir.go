@@ -77,10 +77,22 @@ func (v *dir) List(ctx context.Context) ([]plugin.Entry, error) {
 	return v.generateChildren(&dirMap{mp: dirmap}), nil
 }
 
+func (v *dir) Create(ctx context.Context, name string, content []byte) (plugin.Entry, error) {
+	return createFile(ctx, v, v.impl, v.dirmap, v.path, name, content)
+}
+
+func (v *dir) CreateDir(ctx context.Context, name string) (plugin.Parent, error) {
+	return createDir(ctx, v, v.impl, v.dirmap, v.path, name)
+}
+
 func (v *dir) Delete(ctx context.Context) (bool, error) {
 	return deleteNode(ctx, v.impl, v.path, v.dirmap)
 }
 
+func (v *dir) Rename(ctx context.Context, newParent plugin.Parent, newName string) (plugin.Entry, error) {
+	return renameNode(ctx, v.impl, v.path, v.dirmap, *v.Attributes(), newParent, newName)
+}
+
 const dirDescription = `
 This is a directory on a remote volume or a container/VM.
 `
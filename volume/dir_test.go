@@ -2,6 +2,7 @@ package volume
 
 import (
 	"context"
+	"errors"
 	"io"
 	"os"
 	"strings"
@@ -32,8 +33,9 @@ func (m *mockDirEntry) VolumeStream(context.Context, string) (io.ReadCloser, err
 	return nil, syscall.ENOTSUP
 }
 
-func (m *mockDirEntry) VolumeWrite(context.Context, string, []byte, os.FileMode) error {
-	return syscall.ENOTSUP
+func (m *mockDirEntry) VolumeWrite(ctx context.Context, path string, b []byte, mode os.FileMode) error {
+	args := m.Called(ctx, path, b, mode)
+	return args.Error(0)
 }
 
 func (m *mockDirEntry) VolumeDelete(ctx context.Context, path string) (bool, error) {
@@ -42,6 +44,16 @@ func (m *mockDirEntry) VolumeDelete(ctx context.Context, path string) (bool, err
 	return args.Get(0).(bool), args.Error(1)
 }
 
+func (m *mockDirEntry) VolumeMkdir(ctx context.Context, path string) error {
+	args := m.Called(ctx, path)
+	return args.Error(0)
+}
+
+func (m *mockDirEntry) VolumeRename(ctx context.Context, oldPath string, newPath string) error {
+	args := m.Called(ctx, oldPath, newPath)
+	return args.Error(0)
+}
+
 func (m *mockDirEntry) Schema() *plugin.EntrySchema {
 	return nil
 }
@@ -84,3 +96,52 @@ func TestVolumeDir(t *testing.T) {
 
 	plugin.UnsetTestCache()
 }
+
+func TestVolumeDirCreate(t *testing.T) {
+	dmap, err := ParseStatPOSIX(strings.NewReader(fixture), mountpoint, mountpoint, mountDepth)
+	assert.Nil(t, err)
+
+	plugin.SetTestCache(datastore.NewMemCache())
+	defer plugin.UnsetTestCache()
+	entry := mockDirEntry{EntryBase: plugin.NewEntry("mine")}
+	ctx := context.Background()
+
+	// Prefetched dirs add new children to the dirmap so that they're listed.
+	vd := newDir("path2", dmap[RootPath]["path2"], &entry, "/path2")
+	vd.SetTestID("/mine/path2")
+	vd.dirmap = &dirMap{mp: dmap}
+
+	entry.On("VolumeWrite", mock.Anything, "/path2/new file", []byte("hello"), os.FileMode(0640)).Return(nil).Once()
+	f, err := plugin.Create(ctx, vd, "new file", []byte("hello"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "new file", plugin.Name(f))
+		attr := plugin.Attributes(f)
+		assert.Equal(t, uint64(5), attr.Size())
+	}
+
+	entry.On("VolumeMkdir", mock.Anything, "/path2/new dir").Return(nil).Once()
+	d, err := plugin.CreateDir(ctx, vd, "new dir")
+	if assert.NoError(t, err) {
+		attr := plugin.Attributes(d)
+		assert.Equal(t, os.ModeDir|0750, attr.Mode())
+		children, err := plugin.List(ctx, d)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, children.Len())
+	}
+
+	children, err := plugin.List(ctx, vd)
+	if assert.NoError(t, err) {
+		_, ok := children.Load("new file")
+		assert.True(t, ok)
+		_, ok = children.Load("new dir")
+		assert.True(t, ok)
+	}
+
+	// Existing children aren't overwritten.
+	_, err = plugin.Create(ctx, vd, "dir", []byte{})
+	assert.True(t, errors.Is(err, os.ErrExist))
+	_, err = plugin.CreateDir(ctx, vd, "new dir")
+	assert.True(t, errors.Is(err, os.ErrExist))
+
+	entry.AssertExpectations(t)
+}
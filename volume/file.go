@@ -1,10 +1,11 @@
 package volume
 
 import (
+	"bytes"
 	"context"
 	"io"
+	"io/ioutil"
 	"os"
-	"time"
 
 	"github.com/puppetlabs/wash/plugin"
 )
@@ -25,7 +26,6 @@ func newFile(name string, attr plugin.EntryAttributes, impl Interface, path stri
 	vf.impl = impl
 	vf.path = path
 	vf.SetAttributes(attr)
-	vf.SetTTLOf(plugin.ReadOp, 60*time.Second)
 
 	return vf
 }
@@ -34,9 +34,17 @@ func (v *file) Schema() *plugin.EntrySchema {
 	return plugin.NewEntrySchema(v, "file").SetDescription(fileDescription)
 }
 
-// Read reads the content of the file
-func (v *file) Read(ctx context.Context) ([]byte, error) {
-	return v.impl.VolumeRead(ctx, v.path)
+// ReadStream streams the file's content if the volume's a StreamReader. Otherwise the content's
+// read into memory with VolumeRead.
+func (v *file) ReadStream(ctx context.Context) (io.ReadCloser, error) {
+	if sr, ok := v.impl.(StreamReader); ok {
+		return sr.VolumeReadStream(ctx, v.path)
+	}
+	b, err := v.impl.VolumeRead(ctx, v.path)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(b)), nil
 }
 
 func (v *file) Stream(ctx context.Context) (io.ReadCloser, error) {
@@ -44,18 +52,86 @@ func (v *file) Stream(ctx context.Context) (io.ReadCloser, error) {
 }
 
 func (v *file) Write(ctx context.Context, b []byte) error {
-	// Pass mode for Write operations that replace the file.
-	mode := os.FileMode(0640)
+	return v.impl.VolumeWrite(ctx, v.path, b, v.writeMode())
+}
+
+// WriteStream writes the file's new content as it's copied if the volume's a StreamWriter.
+// Otherwise the content's buffered and written when the returned writer's closed. Abandoned
+// writes cancel the StreamWriter's write, which leaves the file's old content in place.
+func (v *file) WriteStream(ctx context.Context, size int64) (io.WriteCloser, error) {
+	sw, ok := v.impl.(StreamWriter)
+	if !ok {
+		return &bufferedWrite{ctx: ctx, write: func(b []byte) error {
+			return v.impl.VolumeWrite(ctx, v.path, b, v.writeMode())
+		}}, nil
+	}
+
+	r, w := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		err := sw.VolumeWriteFrom(ctx, v.path, r, v.writeMode())
+		// Unblock the writer if the write stopped before reading all of the content.
+		if err != nil {
+			r.CloseWithError(err)
+		} else {
+			r.Close()
+		}
+		done <- err
+	}()
+	return &streamedWrite{ctx: ctx, w: w, done: done}, nil
+}
+
+// writeMode returns the mode passed for Write operations that replace the file.
+func (v *file) writeMode() os.FileMode {
 	if v.Attributes().HasMode() {
-		mode = v.Attributes().Mode()
+		return v.Attributes().Mode()
+	}
+	return os.FileMode(0640)
+}
+
+// bufferedWrite holds a file's new content until it's closed, then writes all of it.
+type bufferedWrite struct {
+	bytes.Buffer
+	ctx   context.Context
+	write func([]byte) error
+}
+
+func (w *bufferedWrite) Close() error {
+	if err := w.ctx.Err(); err != nil {
+		return err
 	}
-	return v.impl.VolumeWrite(ctx, v.path, b, mode)
+	return w.write(w.Bytes())
+}
+
+// streamedWrite pipes a file's new content to a StreamWriter. Closing it waits for the
+// StreamWriter to finish.
+type streamedWrite struct {
+	ctx  context.Context
+	w    *io.PipeWriter
+	done <-chan error
+}
+
+func (w *streamedWrite) Write(p []byte) (int, error) {
+	return w.w.Write(p)
+}
+
+func (w *streamedWrite) Close() error {
+	if err := w.ctx.Err(); err != nil {
+		w.w.CloseWithError(err)
+	} else {
+		w.w.Close()
+	}
+	return <-w.done
 }
 
 func (v *file) Delete(ctx context.Context) (bool, error) {
 	return deleteNode(ctx, v.impl, v.path, v.dirmap)
 }
 
+func (v *file) Rename(ctx context.Context, newParent plugin.Parent, newName string) (plugin.Entry, error) {
+	return renameNode(ctx, v.impl, v.path, v.dirmap, *v.Attributes(), newParent, newName)
+}
+
 const fileDescription = `
 This is a file on a remote volume or a container/VM.
 `
@@ -46,6 +46,14 @@ func (m *mockFileEntry) VolumeWrite(_ context.Context, _ string, b []byte, _ os.
 	return nil
 }
 
+func (m *mockFileEntry) VolumeMkdir(context.Context, string) error {
+	return m.err
+}
+
+func (m *mockFileEntry) VolumeRename(context.Context, string, string) error {
+	return m.err
+}
+
 func (m *mockFileEntry) VolumeDelete(context.Context, string) (bool, error) {
 	return true, nil
 }
@@ -67,7 +75,7 @@ func TestVolumeFile(t *testing.T) {
 	expectedAttr.SetCtime(now)
 	assert.Equal(t, expectedAttr, attr)
 
-	content, err := vf.Read(context.Background())
+	content, err := plugin.Read(context.Background(), vf, 5, 0)
 	assert.Nil(t, err)
 	assert.Equal(t, []byte("hello"), content)
 
@@ -86,11 +94,78 @@ func TestVolumeFile(t *testing.T) {
 	assert.Equal(t, text, impl.content)
 }
 
+// mockStreamFileEntry is a mockFileEntry that's a StreamReader and a StreamWriter.
+type mockStreamFileEntry struct {
+	mockFileEntry
+	streamed bool
+}
+
+func (m *mockStreamFileEntry) VolumeReadStream(context.Context, string) (io.ReadCloser, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.streamed = true
+	return ioutil.NopCloser(strings.NewReader(m.content)), nil
+}
+
+func (m *mockStreamFileEntry) VolumeWriteFrom(_ context.Context, _ string, r io.Reader, _ os.FileMode) error {
+	if m.err != nil {
+		return m.err
+	}
+	b, err := ioutil.ReadAll(r)
+	m.content = string(b)
+	return err
+}
+
+func TestVolumeFileReadStream(t *testing.T) {
+	for _, impl := range []Interface{
+		&mockFileEntry{EntryBase: plugin.NewEntry("parent"), content: "hello"},
+		&mockStreamFileEntry{mockFileEntry: mockFileEntry{EntryBase: plugin.NewEntry("parent"), content: "hello"}},
+	} {
+		vf := newFile("mine", plugin.EntryAttributes{}, impl, "my path")
+		rdr, err := plugin.ReadStream(context.Background(), vf)
+		if assert.NoError(t, err) {
+			buf, err := ioutil.ReadAll(rdr)
+			assert.NoError(t, err)
+			assert.Equal(t, "hello", string(buf))
+		}
+		if sr, ok := impl.(*mockStreamFileEntry); ok {
+			assert.True(t, sr.streamed)
+		}
+	}
+}
+
+func TestVolumeFileWriteStream(t *testing.T) {
+	for _, impl := range []Interface{
+		&mockFileEntry{EntryBase: plugin.NewEntry("parent")},
+		&mockStreamFileEntry{mockFileEntry: mockFileEntry{EntryBase: plugin.NewEntry("parent")}},
+	} {
+		vf := newFile("mine", plugin.EntryAttributes{}, impl, "my path")
+		assert.NoError(t, plugin.WriteFrom(context.Background(), vf, strings.NewReader("some text"), 4))
+		content, err := impl.VolumeRead(context.Background(), "my path")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("some"), content)
+	}
+}
+
+func TestVolumeFileWriteStreamErr(t *testing.T) {
+	impl := &mockStreamFileEntry{mockFileEntry: mockFileEntry{EntryBase: plugin.NewEntry("parent"), err: errors.New("fail")}}
+	vf := newFile("mine", plugin.EntryAttributes{}, impl, "my path")
+
+	w, err := vf.WriteStream(context.Background(), 4)
+	if assert.NoError(t, err) {
+		// Writes fail rather than block once the StreamWriter stops reading.
+		_, err = w.Write([]byte("some"))
+		assert.Equal(t, errors.New("fail"), err)
+		assert.Equal(t, errors.New("fail"), w.Close())
+	}
+}
+
 func TestVolumeFileErr(t *testing.T) {
 	impl := &mockFileEntry{EntryBase: plugin.NewEntry("parent"), err: errors.New("fail")}
 	vf := newFile("mine", plugin.EntryAttributes{}, impl, "my path")
 
-	content, err := vf.Read(context.Background())
+	content, err := vf.ReadStream(context.Background())
 	assert.Nil(t, content)
 	assert.Equal(t, errors.New("fail"), err)
 
@@ -54,6 +54,16 @@ func (d *FS) List(ctx context.Context) ([]plugin.Entry, error) {
 	return List(ctx, d)
 }
 
+// Create creates a file in the root directory.
+func (d *FS) Create(ctx context.Context, name string, content []byte) (plugin.Entry, error) {
+	return Create(ctx, d, name, content)
+}
+
+// CreateDir creates a directory in the root directory.
+func (d *FS) CreateDir(ctx context.Context, name string) (plugin.Parent, error) {
+	return CreateDir(ctx, d, name)
+}
+
 type nonZeroError struct {
 	cmdline  []string
 	stderr   string
@@ -156,6 +166,58 @@ func (d *FS) VolumeRead(ctx context.Context, path string) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// VolumeReadStream satisfies the StreamReader interface. The file's content is piped from the
+// command that reads it as the command outputs it.
+func (d *FS) VolumeReadStream(ctx context.Context, path string) (io.ReadCloser, error) {
+	command := d.selectShellCommand([]string{"cat", path}, []string{"Get-Content '" + path + "'"})
+	activity.Record(ctx, "Running %v on %v", command, d.executor)
+
+	// Don't use Tty when outputting file content because it may convert LF to CRLF.
+	execOpts := plugin.ExecOptions{Elevate: true}
+	cmd, err := plugin.Exec(ctx, d.executor, command[0], command[1:], execOpts)
+	if err != nil {
+		activity.Record(ctx, "Exec error in VolumeReadStream: %v", err)
+		return nil, err
+	}
+
+	r, w := io.Pipe()
+	go func() {
+		var stderr bytes.Buffer
+		var errs []error
+		for chunk := range cmd.OutputCh() {
+			if chunk.Err != nil {
+				errs = append(errs, chunk.Err)
+				continue
+			}
+
+			switch chunk.StreamID {
+			case plugin.Stdout:
+				if len(errs) == 0 {
+					if _, err := w.Write([]byte(chunk.Data)); err != nil {
+						activity.Record(ctx, "Error copying exec result: %v", err)
+						errs = append(errs, err)
+					}
+				}
+			case plugin.Stderr:
+				activity.Record(ctx, "%v: %v", chunk.StreamID, chunk.Data)
+				fmt.Fprint(&stderr, chunk.Data)
+			}
+		}
+
+		if len(errs) > 0 {
+			err = w.CloseWithError(fmt.Errorf("exec errored: %v", errs))
+		} else if exitcode, exitErr := cmd.ExitCode(); exitErr != nil {
+			err = w.CloseWithError(exitErr)
+		} else if exitcode != 0 {
+			err = w.CloseWithError(nonZeroError{cmdline: command, stderr: strings.TrimSpace(stderr.String()), exitcode: exitcode})
+		} else {
+			err = w.Close()
+		}
+		activity.Record(ctx, "Closing write pipe: %v", err)
+	}()
+	return r, nil
+}
+
 // VolumeStream satisfies the Interface required by List to stream file contents.
 func (d *FS) VolumeStream(ctx context.Context, path string) (io.ReadCloser, error) {
 	command := d.selectShellCommand(
@@ -202,13 +264,37 @@ func (d *FS) VolumeStream(ctx context.Context, path string) (io.ReadCloser, erro
 }
 
 // VolumeWrite satisfies the Interface required by Write to write content to a file.
-func (d *FS) VolumeWrite(ctx context.Context, path string, b []byte, _ os.FileMode) error {
-	command := d.selectShellCommand([]string{"cp", "/dev/stdin", path}, []string{"$input | Set-Content '" + path + "'"})
+func (d *FS) VolumeWrite(ctx context.Context, path string, b []byte, m os.FileMode) error {
+	return d.VolumeWriteFrom(ctx, path, bytes.NewReader(b), m)
+}
+
+// writePOSIX writes stdin to $1. The content's written to a temporary file that replaces $1
+// only once it's complete, so $1 is left as it was if the write fails. The temporary file
+// gets $1's mode and owner where chmod and chown support --reference.
+const writePOSIX = `tmp="$1.wash-$$"
+trap 'rm -f "$tmp"' EXIT
+cat > "$tmp" || exit
+if [ -e "$1" ]; then
+  chmod --reference="$1" "$tmp" 2>/dev/null
+  chown --reference="$1" "$tmp" 2>/dev/null
+fi
+mv -f "$tmp" "$1"`
+
+// VolumeWriteFrom satisfies the StreamWriter interface. The content's piped to the command
+// that writes the file as it's read from r. It's written to a temporary file that replaces
+// the file only after it's completely written.
+func (d *FS) VolumeWriteFrom(ctx context.Context, path string, r io.Reader, _ os.FileMode) error {
+	tmp := path + ".wash-tmp"
+	command := d.selectShellCommand(
+		[]string{"sh", "-c", writePOSIX, "sh", path},
+		[]string{"$input | Set-Content '" + tmp + "'; if (-not $?) { Remove-Item -Force '" + tmp + "'; exit 1 }; " +
+			"Move-Item -Force -Path '" + tmp + "' -Destination '" + path + "'"},
+	)
 	activity.Record(ctx, "Running %v on %v", command, d.executor)
 
 	// Don't use Tty when writing file content because it may convert LF to CRLF.
 	// Use Elevate because it's common to login to systems as a non-root user and sudo.
-	opts := plugin.ExecOptions{Elevate: true, Stdin: bytes.NewReader(b)}
+	opts := plugin.ExecOptions{Elevate: true, Stdin: r}
 	cmd, err := plugin.Exec(ctx, d.executor, command[0], command[1:], opts)
 	if err != nil {
 		return err
@@ -255,6 +341,43 @@ func (d *FS) VolumeDelete(ctx context.Context, path string) (bool, error) {
 	return true, nil
 }
 
+// VolumeMkdir satisfies the Interface required by CreateDir to create directories.
+func (d *FS) VolumeMkdir(ctx context.Context, path string) error {
+	command := d.selectShellCommand(
+		[]string{"mkdir", path},
+		[]string{"New-Item -ItemType Directory -Path '" + path + "' | Out-Null"},
+	)
+
+	// Skip tty because we don't need it, we ignore the output.
+	if _, err := exec(ctx, d.executor, command, false); err != nil {
+		activity.Record(ctx, "Exec error running 'mkdir %v' in VolumeMkdir: %v", path, err)
+		return err
+	}
+	return nil
+}
+
+// VolumeRename satisfies the Interface required by Rename to move volume nodes.
+func (d *FS) VolumeRename(ctx context.Context, oldPath string, newPath string) error {
+	command := d.selectShellCommand(
+		RenameCmdPOSIX(oldPath, newPath),
+		[]string{"if (Test-Path -LiteralPath '" + newPath + "' -PathType Container) { exit 17 }; " +
+			"Move-Item -Path '" + oldPath + "' -Destination '" + newPath + "'"},
+	)
+
+	// Skip tty because we don't need it, we ignore the output.
+	_, err := exec(ctx, d.executor, command, false)
+	if nzerr, ok := err.(nonZeroError); ok {
+		if errno, ok := RenameErrno(nzerr.exitcode); ok {
+			return fmt.Errorf("could not move %v to %v: %w", oldPath, newPath, errno)
+		}
+	}
+	if err != nil {
+		activity.Record(ctx, "Exec error running 'mv %v %v' in VolumeRename: %v", oldPath, newPath, err)
+		return err
+	}
+	return nil
+}
+
 // Selects between a posix and powershell command based on the entry's login shell.
 // Note that powershell commands are often a single string because they represent a PowerShell
 // expression, and it's easier to pass that as a string than try to correctly escape it as
@@ -289,4 +412,9 @@ Note that Wash will exec a command on the container/VM whenever it invokes a
 List/Read/Stream action on a directory/file, and the action's result is not
 currently cached. For List, that command is 'find -exec stat'. For Read, that
 command is 'cat'. For Stream, that command is 'tail -f'.
+
+You can also create files and directories (e.g. with 'touch' and 'mkdir').
+Wash creates files the same way it writes them, and runs 'mkdir' to create
+directories. Moving files and directories within the filesystem with 'mv'
+runs 'mv' on the container/VM.
 `
@@ -3,9 +3,12 @@ package volume
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"sort"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -78,6 +81,11 @@ type fsTestSuite struct {
 	outputDepth                        int
 	shortFixture, deepFixture          string
 	readCmdFn, writeCmdFn, deleteCmdFn func(path string) (command []string)
+	renameCmdFn                        func(oldPath, newPath string) (command []string)
+	// renameDirExit is the rename command's exit code when a file's moved onto a directory,
+	// and renameDirErr is the error it's reported as.
+	renameDirExit int
+	renameDirErr  syscall.Errno
 }
 
 func (suite *fsTestSuite) SetupTest() {
@@ -166,7 +174,7 @@ func (suite *fsTestSuite) TestFSList() {
 	if suite.NoError(err) {
 		suite.Equal(1, len(entries1))
 		suite.Equal("a file", plugin.Name(entries1[0]))
-		_, ok := entries1[0].(plugin.Readable)
+		_, ok := entries1[0].(plugin.SequentialReadable)
 		suite.True(ok)
 	}
 
@@ -244,9 +252,36 @@ func (suite *fsTestSuite) TestFSRead() {
 	suite.Equal("a file", plugin.Name(entry))
 	exec.onExec(suite.readCmdFn("/var/log/path1/a file"), suite.createResult("hello"))
 
-	content, err := entry.(plugin.Readable).Read(suite.ctx)
-	suite.NoError(err)
-	suite.Equal([]byte("hello"), content)
+	rdr, err := entry.(plugin.SequentialReadable).ReadStream(suite.ctx)
+	if suite.NoError(err) {
+		content, err := ioutil.ReadAll(rdr)
+		suite.NoError(err)
+		suite.Equal([]byte("hello"), content)
+	}
+	exec.AssertExpectations(suite.T())
+}
+
+func (suite *fsTestSuite) TestFSReadNonZeroExit() {
+	exec := suite.createExec()
+	exec.onExec(suite.statCmd("/", suite.outputDepth), suite.createResult(suite.outputFixture))
+	fs := NewFS(suite.ctx, "fs", exec, suite.outputDepth)
+
+	result := plugin.NewExecCommand(suite.ctx)
+	go func() {
+		_, err := result.Stderr().Write([]byte("permission denied"))
+		suite.NoError(err)
+		result.CloseStreamsWithError(nil)
+		result.SetExitCode(1)
+	}()
+	exec.onExec(suite.readCmdFn("/a file"), result)
+
+	// The command's failure is reported when the stream's read.
+	rdr, err := fs.VolumeReadStream(suite.ctx, "/a file")
+	if suite.NoError(err) {
+		_, err = ioutil.ReadAll(rdr)
+		suite.Error(err)
+		suite.Contains(err.Error(), "permission denied")
+	}
 	exec.AssertExpectations(suite.T())
 }
 
@@ -281,6 +316,33 @@ func (suite *fsTestSuite) TestVolumeDelete() {
 	exec.AssertExpectations(suite.T())
 }
 
+func (suite *fsTestSuite) TestVolumeRename() {
+	exec := suite.createExec()
+	exec.onExec(suite.statCmd("/", suite.outputDepth), suite.createResult(suite.outputFixture))
+	fs := NewFS(suite.ctx, "fs", exec, suite.outputDepth)
+
+	exec.onExec(suite.renameCmdFn("/a file", "/b file"), suite.createResult(""))
+	suite.NoError(fs.VolumeRename(suite.ctx, "/a file", "/b file"))
+	exec.AssertExpectations(suite.T())
+}
+
+func (suite *fsTestSuite) TestVolumeRename_OntoDir() {
+	exec := suite.createExec()
+	exec.onExec(suite.statCmd("/", suite.outputDepth), suite.createResult(suite.outputFixture))
+	fs := NewFS(suite.ctx, "fs", exec, suite.outputDepth)
+
+	// The file isn't moved into the directory.
+	result := plugin.NewExecCommand(suite.ctx)
+	go func() {
+		result.CloseStreamsWithError(nil)
+		result.SetExitCode(suite.renameDirExit)
+	}()
+	exec.onExec(suite.renameCmdFn("/a file", "/var"), result)
+	err := fs.VolumeRename(suite.ctx, "/a file", "/var")
+	suite.True(errors.Is(err, suite.renameDirErr), "%v is not %v", err, suite.renameDirErr)
+	exec.AssertExpectations(suite.T())
+}
+
 func TestPOSIXFS(t *testing.T) {
 	suite.Run(t, &fsTestSuite{
 		loginShell:    plugin.POSIXShell,
@@ -290,8 +352,13 @@ func TestPOSIXFS(t *testing.T) {
 		shortFixture:  posixFixtureShort,
 		deepFixture:   posixFixtureDeep,
 		readCmdFn:     func(path string) []string { return []string{"cat", path} },
-		writeCmdFn:    func(path string) []string { return []string{"cp", "/dev/stdin", path} },
+		writeCmdFn:    func(path string) []string { return []string{"sh", "-c", writePOSIX, "sh", path} },
 		deleteCmdFn:   func(path string) []string { return []string{"rm", "-rf", path} },
+		renameCmdFn: func(oldPath, newPath string) []string {
+			return RenameCmdPOSIX(oldPath, newPath)
+		},
+		renameDirExit: 21,
+		renameDirErr:  syscall.EISDIR,
 	})
 }
 
@@ -304,8 +371,17 @@ func TestPowershellFS(t *testing.T) {
 		shortFixture:  powershellFixtureShort,
 		deepFixture:   powershellFixtureDeep,
 		readCmdFn:     func(path string) []string { return []string{"Get-Content '" + path + "'"} },
-		writeCmdFn:    func(path string) []string { return []string{"$input | Set-Content '" + path + "'"} },
-		deleteCmdFn:   func(path string) []string { return []string{"Remove-Item -Recurse -Force '" + path + "'"} },
+		writeCmdFn: func(path string) []string {
+			return []string{"$input | Set-Content '" + path + ".wash-tmp'; if (-not $?) { Remove-Item -Force '" + path + ".wash-tmp'; exit 1 }; " +
+				"Move-Item -Force -Path '" + path + ".wash-tmp' -Destination '" + path + "'"}
+		},
+		deleteCmdFn: func(path string) []string { return []string{"Remove-Item -Recurse -Force '" + path + "'"} },
+		renameCmdFn: func(oldPath, newPath string) []string {
+			return []string{"if (Test-Path -LiteralPath '" + newPath + "' -PathType Container) { exit 17 }; " +
+				"Move-Item -Path '" + oldPath + "' -Destination '" + newPath + "'"}
+		},
+		renameDirExit: 17,
+		renameDirErr:  syscall.EEXIST,
 	})
 }
 
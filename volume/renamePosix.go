@@ -0,0 +1,33 @@
+package volume
+
+import "syscall"
+
+// renamePOSIX moves $1 to $2 like rename(2). Plain mv moves $1 into $2 when $2 is a
+// directory, so that case is checked first and reported with the exit codes in renameErrnos.
+const renamePOSIX = `if [ -d "$2" ] && [ ! -L "$2" ]; then
+  [ -d "$1" ] || exit 21
+  rmdir "$2" 2>/dev/null || exit 39
+elif [ -e "$2" ] && [ -d "$1" ]; then
+  exit 20
+fi
+mv -f "$1" "$2"`
+
+// renameErrnos maps the exit codes of the rename commands to the errors that rename(2) returns.
+var renameErrnos = map[int]syscall.Errno{
+	17: syscall.EEXIST,
+	20: syscall.ENOTDIR,
+	21: syscall.EISDIR,
+	39: syscall.ENOTEMPTY,
+}
+
+// RenameCmdPOSIX returns the POSIX command that moves oldPath to newPath like rename(2).
+func RenameCmdPOSIX(oldPath string, newPath string) []string {
+	return []string{"sh", "-c", renamePOSIX, "sh", oldPath, newPath}
+}
+
+// RenameErrno returns the error that rename(2) reports for an exit code of RenameCmdPOSIX. It
+// returns false if the command failed for some other reason.
+func RenameErrno(exitcode int) (syscall.Errno, bool) {
+	errno, ok := renameErrnos[exitcode]
+	return errno, ok
+}